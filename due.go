@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/cli"
+)
+
+func runDue(args []string) error {
+	config, err := cli.ParseDueArgs(args)
+	if err != nil {
+		return err
+	}
+	if config.Help {
+		cli.ShowDueHelp()
+		return nil
+	}
+
+	calendar, err := config.Calendar()
+	if err != nil {
+		return err
+	}
+	due := calculator.DueDate(calendar, config.Issued, config.Net, config.Business)
+	fmt.Println(cli.FormatDueDate(due, config))
+	return nil
+}
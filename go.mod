@@ -1,3 +1,3 @@
-module billme
+module github.com/honzahovorka/billme
 
 go 1.24.5
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/cli"
+)
+
+func runHolidays(args []string) error {
+	config, err := cli.ParseHolidaysArgs(args)
+	if err != nil {
+		return err
+	}
+	if config.Help {
+		cli.ShowHolidaysHelp()
+		return nil
+	}
+
+	list, err := cli.Holidays(config)
+	if err != nil {
+		return err
+	}
+	output, err := cli.FormatHolidays(list, config)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	if config.Output != cli.OutputICS {
+		fmt.Println()
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/cli"
+)
+
+func runICS(args []string) error {
+	config, err := cli.ParseICSArgs(args)
+	if err != nil {
+		return err
+	}
+	if config.Help {
+		cli.ShowICSHelp()
+		return nil
+	}
+
+	output, err := cli.ICS(config)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
@@ -0,0 +1,129 @@
+package calculator
+
+import (
+	"github.com/honzahovorka/billme/internal/holidays"
+	"time"
+)
+
+// DayKind classifies a day of a month breakdown.
+type DayKind int
+
+const (
+	Workday DayKind = iota
+	Weekend
+	Holiday
+	Vacation
+	Partial // a workday with part of it taken off
+)
+
+var dayKindNames = [...]string{"workday", "weekend", "holiday", "vacation", "partial"}
+
+func (k DayKind) String() string {
+	return dayKindNames[k]
+}
+
+// Day is one day of a month breakdown.
+type Day struct {
+	Date    time.Time
+	Weekday time.Weekday
+	Kind    DayKind
+	// Holiday is the local name of the public holiday on this date, if any.
+	// It is set even when the holiday is a weekend or counted as a workday.
+	Holiday string
+	// Holidays are the holidays on this date with their metadata, the first
+	// of them named by Holiday.
+	Holidays []holidays.Holiday
+	// WorkingDay is the special working day on this date, a weekend day
+	// worked to make up for a day off, if any.
+	WorkingDay *holidays.Holiday
+	// Weight is the part of the day that is billable: 1 for a workday, 0 for
+	// a weekend, excluded holiday or vacation, and in between for a partial
+	// day.
+	Weight float64
+}
+
+// Leave is time off on a date. Fraction is the part of the day taken off;
+// zero means the whole day.
+type Leave struct {
+	Date     time.Time
+	Fraction float64
+}
+
+// Breakdown classifies every day of the month. Public holidays of country
+// are named, and only excluded from the workdays when excludeHolidays is
+// set. Workdays with leave become vacation or partial days. A month outside
+// MinYear-MaxYear has no days, as its holidays are unknown.
+//
+// Deprecated: use Calculate and its Result.Days.
+func Breakdown(month, year int, country string, excludeHolidays bool, leave []Leave) []Day {
+	result, _ := CalculateMonth(month, year, Options{Country: country, ExcludeHolidays: excludeHolidays, Leave: leave})
+	return result.Days
+}
+
+// classify returns the day of date given the holidays and special working
+// days of its year. The day is a holiday when one of the excluded types
+// falls on it. The special working days are worked only when holidays are
+// excluded, as they make up for days off.
+func classify(date time.Time, holidayList, workingDays []holidays.Holiday, workWeek WorkWeek, excluded holidays.HolidayTypes, leave []Leave) Day {
+	day := Day{Date: date, Weekday: date.Weekday(), Kind: Workday, Weight: 1}
+	for i := range workingDays {
+		if excluded != 0 && sameDay(workingDays[i].Date, date) {
+			day.WorkingDay = &workingDays[i]
+		}
+	}
+	dayOff := false
+	for _, holiday := range holidayList {
+		if sameDay(holiday.Date, date) {
+			day.Holidays = append(day.Holidays, holiday)
+			dayOff = dayOff || excluded.Has(holiday.Type)
+		}
+	}
+	if len(day.Holidays) > 0 {
+		day.Holiday = day.Holidays[0].Name
+	}
+
+	switch off, onLeave := leaveOn(leave, date); {
+	case !workWeek.Has(day.Weekday) && day.WorkingDay == nil:
+		day.Kind, day.Weight = Weekend, 0
+	case dayOff:
+		day.Kind, day.Weight = Holiday, 0
+	case onLeave && off < 1:
+		day.Kind, day.Weight = Partial, 1-off
+	case onLeave:
+		day.Kind, day.Weight = Vacation, 0
+	}
+	return day
+}
+
+// leaveOn returns the part of day taken off, if any leave falls on it.
+func leaveOn(leave []Leave, day time.Time) (float64, bool) {
+	for _, l := range leave {
+		if sameDay(l.Date, day) {
+			if l.Fraction <= 0 || l.Fraction >= 1 {
+				return 1, true
+			}
+			return l.Fraction, true
+		}
+	}
+	return 0, false
+}
+
+// CountKind counts the days of kind in a breakdown.
+func CountKind(days []Day, kind DayKind) int {
+	count := 0
+	for _, day := range days {
+		if day.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Weight sums the billable part of the days in a breakdown.
+func Weight(days []Day) float64 {
+	total := 0.0
+	for _, day := range days {
+		total += day.Weight
+	}
+	return total
+}
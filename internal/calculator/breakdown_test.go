@@ -0,0 +1,71 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreakdown(t *testing.T) {
+	leave := []Leave{{Date: date(2024, 7, 8)}, {Date: date(2024, 7, 9), Fraction: 0.5}, {Date: date(2024, 7, 13)}}
+	days := Breakdown(7, 2024, "CZ", true, leave)
+
+	if len(days) != 31 {
+		t.Fatalf("Breakdown() returned %d days, want 31", len(days))
+	}
+
+	tests := []struct {
+		day     int
+		weekday time.Weekday
+		kind    DayKind
+		holiday string
+		weight  float64
+	}{
+		{1, time.Monday, Workday, "", 1},
+		{5, time.Friday, Holiday, "Den slovanských věrozvěstů Cyrila a Metoděje", 0},
+		{6, time.Saturday, Weekend, "Den upálení mistra Jana Husa", 0},
+		{8, time.Monday, Vacation, "", 0},
+		{9, time.Tuesday, Partial, "", 0.5},
+		{13, time.Saturday, Weekend, "", 0},
+	}
+
+	for _, tt := range tests {
+		day := days[tt.day-1]
+		if day.Weekday != tt.weekday || day.Kind != tt.kind || day.Holiday != tt.holiday || day.Weight != tt.weight {
+			t.Errorf("July %d = %v %v %q %v; want %v %v %q %v", tt.day, day.Weekday, day.Kind, day.Holiday, day.Weight,
+				tt.weekday, tt.kind, tt.holiday, tt.weight)
+		}
+	}
+
+	if got := CountKind(days, Workday); got != 20 {
+		t.Errorf("CountKind(Workday) = %d; want 20", got)
+	}
+	if got := Weight(days); got != 20.5 {
+		t.Errorf("Weight() = %v; want 20.5", got)
+	}
+}
+
+func TestBreakdownIncludedHoliday(t *testing.T) {
+	days := Breakdown(7, 2024, "CZ", false, nil)
+	if day := days[4]; day.Kind != Workday || day.Holiday == "" {
+		t.Errorf("July 5 = %v %q; want a named workday", day.Kind, day.Holiday)
+	}
+}
+
+func TestBreakdownHolidayMetadata(t *testing.T) {
+	days := Breakdown(7, 2024, "CZ", true, nil)
+	if day := days[5]; len(day.Holidays) != 1 || day.Holidays[0].ID != "jan-hus-day" || day.Holiday != day.Holidays[0].Name {
+		t.Errorf("July 6 holidays = %+v; want Jan Hus Day", day.Holidays)
+	}
+	if day := days[6]; day.Holidays != nil {
+		t.Errorf("July 7 holidays = %+v; want none", day.Holidays)
+	}
+}
+
+func TestBreakdownMatchesCounts(t *testing.T) {
+	for month := 1; month <= 12; month++ {
+		days := Breakdown(month, 2024, "CZ", true, nil)
+		if got, want := int(Weight(days)), CountWorkingDaysWithHolidays(month, 2024, "CZ", true); got != want {
+			t.Errorf("month %d: Weight() = %d; CountWorkingDaysWithHolidays() = %d", month, got, want)
+		}
+	}
+}
@@ -1,45 +1,301 @@
 package calculator
 
 import (
-	"billme/internal/holidays"
+	"github.com/honzahovorka/billme/internal/holidays"
+	"strings"
 	"time"
 )
 
+// Options configure Calculate. The zero value counts Monday to Friday with
+// no holidays and no leave.
+type Options struct {
+	// Country selects the holiday provider by ISO 3166-1 code; empty means
+	// no holidays.
+	Country string
+	// Region narrows the holidays to a subdivision of the country, for
+	// providers that implement holidays.RegionalProvider.
+	Region string
+	// Provider supplies the holidays instead of Country when set.
+	Provider holidays.HolidayProvider
+	// Intersect narrows the holidays of several countries combined in
+	// Country to the days off they all share; see holidays.Intersection.
+	Intersect bool
+	// ExcludeHolidays leaves public holidays out of the workdays and adds
+	// the special working days of a holidays.WorkingDayProvider. Without it
+	// holidays are still named but count as workdays.
+	ExcludeHolidays bool
+	// HolidayTypes are the types of holiday ExcludeHolidays leaves out;
+	// zero means holidays.DefaultHolidayTypes.
+	HolidayTypes holidays.HolidayTypes
+	// WorkWeek is the set of weekdays normally worked; zero means
+	// DefaultWorkWeek.
+	WorkWeek WorkWeek
+	// Leave is time off on specific dates, whole or partial days.
+	Leave []Leave
+	// VacationDays is a number of undated days off, taken from the total.
+	VacationDays int
+	// Today splits the billable days into elapsed and remaining; the zero
+	// value leaves both at zero.
+	Today time.Time
+}
+
+// Result is the outcome of Calculate.
+type Result struct {
+	Days         []Day   // every day of the period in order
+	WorkingDays  int     // workdays before any leave
+	Holidays     int     // public holidays excluded from the workdays
+	VacationDays float64 // dated leave on workdays plus Options.VacationDays
+	Billable     float64 // WorkingDays less VacationDays, never below zero
+	Elapsed      float64 // billable days before Options.Today
+	Remaining    float64 // billable days from Options.Today on, less Options.VacationDays
+}
+
+// Calculate classifies every day from start through end inclusive and
+// counts the billable days. Invalid input is reported with a *YearError,
+// *PeriodError, *CountryError, *RegionError or *VacationError. When the vacation exceeds
+// the working days the result is still valid, billing zero days, and the
+// error is an *ExcessVacationError.
+func Calculate(start, end time.Time, opts Options) (Result, error) {
+	start = dateOf(start)
+	end = dateOf(end)
+	today := dateOf(opts.Today)
+
+	for _, year := range []int{start.Year(), end.Year()} {
+		if year < MinYear || year > MaxYear {
+			return Result{}, &YearError{Year: year}
+		}
+	}
+	if end.Before(start) {
+		return Result{}, &PeriodError{Start: start, End: end}
+	}
+	if opts.VacationDays < 0 {
+		return Result{}, &VacationError{Days: opts.VacationDays}
+	}
+
+	workWeek := opts.WorkWeek
+	if workWeek == 0 {
+		workWeek = DefaultWorkWeek
+	}
+	provider, err := resolveProvider(opts.Provider, opts.Country, opts.Region, opts.Intersect, opts.HolidayTypes)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var excluded holidays.HolidayTypes
+	if opts.ExcludeHolidays {
+		excluded = opts.HolidayTypes
+		if excluded == 0 {
+			excluded = holidays.DefaultHolidayTypes
+		}
+	}
+
+	var result Result
+	holidayList := map[int][]holidays.Holiday{}
+	workingDays := map[int][]holidays.Holiday{}
+	billable := 0.0
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		list, ok := holidayList[date.Year()]
+		if !ok && provider != nil {
+			list = holidays.ForRegion(provider, date.Year(), opts.Region)
+			holidayList[date.Year()] = list
+			workingDays[date.Year()] = holidays.WorkingDays(provider, date.Year())
+		}
+
+		day := classify(date, list, workingDays[date.Year()], workWeek, excluded, opts.Leave)
+		switch day.Kind {
+		case Holiday:
+			result.Holidays++
+		case Workday, Vacation, Partial:
+			result.WorkingDays++
+		}
+
+		billable += day.Weight
+		if !opts.Today.IsZero() {
+			if date.Before(today) {
+				result.Elapsed += day.Weight
+			} else {
+				result.Remaining += day.Weight
+			}
+		}
+		result.Days = append(result.Days, day)
+	}
+
+	result.VacationDays = float64(result.WorkingDays) - billable + float64(opts.VacationDays)
+	result.Billable = max(billable-float64(opts.VacationDays), 0)
+	result.Remaining = max(result.Remaining-float64(opts.VacationDays), 0)
+	if result.VacationDays > float64(result.WorkingDays) {
+		return result, &ExcessVacationError{VacationDays: result.VacationDays, WorkingDays: float64(result.WorkingDays)}
+	}
+	return result, nil
+}
+
+// LookupProvider returns the holiday provider of an ISO 3166-1 country
+// code, or a *CountryError. A code with a region, as in "DE-BY", or several
+// joined by "+", as in "CZ+DE-BY", give a *holidays.CombinedProvider of
+// their union, which attributes every holiday to its jurisdiction; a
+// region it has no holidays for is a *RegionError.
+func LookupProvider(country string) (holidays.HolidayProvider, error) {
+	if !strings.ContainsAny(country, "+-") {
+		provider, ok := holidays.Lookup(country)
+		if !ok {
+			return nil, &CountryError{Country: country}
+		}
+		return provider, nil
+	}
+
+	combined := &holidays.CombinedProvider{}
+	for _, code := range strings.Split(country, "+") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		name, region, _ := strings.Cut(code, "-")
+		provider, ok := holidays.Lookup(name)
+		if !ok {
+			return nil, &CountryError{Country: code}
+		}
+		if region != "" {
+			if err := CheckRegion(provider, name, region); err != nil {
+				return nil, err
+			}
+		}
+		combined.Jurisdictions = append(combined.Jurisdictions, holidays.Jurisdiction{Code: code, Region: region, Provider: provider})
+	}
+	return combined, nil
+}
+
+// ResolveProvider returns the holiday provider of country, as
+// LookupProvider does, with a region checked by CheckRegion and, with
+// intersect, narrowed by holidays.Intersection to the days off of types
+// shared by all the countries combined in it. Every command selects its
+// holidays this way.
+func ResolveProvider(country, region string, intersect bool, types holidays.HolidayTypes) (holidays.HolidayProvider, error) {
+	return resolveProvider(nil, country, region, intersect, types)
+}
+
+// resolveProvider is ResolveProvider for provider, looked up by country
+// when it is nil; no provider and no country give no holidays.
+func resolveProvider(provider holidays.HolidayProvider, country, region string, intersect bool, types holidays.HolidayTypes) (holidays.HolidayProvider, error) {
+	if provider == nil {
+		if country == "" {
+			return nil, nil
+		}
+		var err error
+		if provider, err = LookupProvider(country); err != nil {
+			return nil, err
+		}
+	}
+	if region != "" {
+		if err := CheckRegion(provider, country, region); err != nil {
+			return nil, err
+		}
+	}
+	if intersect {
+		provider = holidays.Intersection(provider, types)
+	}
+	return provider, nil
+}
+
+// CheckRegion returns a *RegionError unless provider has holidays for
+// region. Providers that do not list their regions accept any.
+func CheckRegion(provider holidays.HolidayProvider, country, region string) error {
+	if holidays.KnownRegion(provider, region) {
+		return nil
+	}
+	err := &RegionError{Country: strings.ToUpper(country), Region: region}
+	if lister, ok := provider.(holidays.RegionLister); ok {
+		err.Regions = lister.Regions()
+	}
+	return err
+}
+
+// CalculateMonth is Calculate for a calendar month, reporting a month
+// outside 1-12 with a *MonthError.
+func CalculateMonth(month, year int, opts Options) (Result, error) {
+	if month < 1 || month > 12 {
+		return Result{}, &MonthError{Month: month}
+	}
+	start, end := Month(month, year)
+	return Calculate(start, end, opts)
+}
+
+// Month returns the first and last day of a month.
+func Month(month, year int) (time.Time, time.Time) {
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return first, first.AddDate(0, 1, -1)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// The deprecated functions below count zero days for invalid input; use
+// Calculate or CalculateMonth to learn why. Unless they exclude holidays,
+// they count the weekdays of any year, MinYear-MaxYear only limiting the
+// years with holidays.
+
+// Deprecated: use Calculate.
 func CountWorkingDays(month, year int) int {
 	return CountWorkingDaysWithHolidays(month, year, "", false)
 }
 
+// Deprecated: use Calculate with Options.Country and ExcludeHolidays.
 func CountWorkingDaysWithHolidays(month, year int, country string, excludeHolidays bool) int {
 	return CountWorkingDaysWithHolidaysAndVacation(month, year, country, excludeHolidays, 0)
 }
 
+// Deprecated: use Calculate with Options.Country, ExcludeHolidays and
+// VacationDays.
 func CountWorkingDaysWithHolidaysAndVacation(month, year int, country string, excludeHolidays bool, vacationDays int) int {
-	firstDay := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	lastDay := firstDay.AddDate(0, 1, -1)
+	return CountWorkingDaysInRange(month, year, month, year, country, excludeHolidays, vacationDays)
+}
 
-	var holidayList []holidays.Holiday
-	if excludeHolidays && country != "" {
-		provider := holidays.GetProvider(country)
-		holidayList = provider.GetHolidays(year)
+// CountWorkingDaysInRange counts the working days of every month from
+// month/year through endMonth/endYear inclusive. Vacation days are taken
+// from the total once, never going below zero.
+//
+// Deprecated: use Calculate.
+func CountWorkingDaysInRange(month, year, endMonth, endYear int, country string, excludeHolidays bool, vacationDays int) int {
+	if month < 1 || month > 12 || endMonth < 1 || endMonth > 12 {
+		return 0
 	}
+	start, _ := Month(month, year)
+	_, end := Month(endMonth, endYear)
+	result := calculateDeprecated(start, end, Options{Country: country, ExcludeHolidays: excludeHolidays, VacationDays: vacationDays})
+	return int(result.Billable)
+}
 
-	workingDays := 0
-
-	for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
-		weekday := day.Weekday()
-		if weekday >= time.Monday && weekday <= time.Friday {
-			if excludeHolidays && holidays.IsHoliday(day, holidayList) {
-				continue
-			}
-			workingDays++
-		}
+// SplitWorkingDays splits the month's working days at today: elapsed days
+// are those before today, remaining days run from today (which can still be
+// billed) to the end of the month. Vacation days are taken from the
+// remaining days, never going below zero.
+//
+// Deprecated: use Calculate with Options.Today.
+func SplitWorkingDays(month, year int, today time.Time, country string, excludeHolidays bool, vacationDays int) (elapsed, remaining int) {
+	if month < 1 || month > 12 {
+		return 0, 0
 	}
+	start, end := Month(month, year)
+	result := calculateDeprecated(start, end, Options{Country: country, ExcludeHolidays: excludeHolidays, VacationDays: vacationDays, Today: today})
+	return int(result.Elapsed), int(result.Remaining)
+}
 
-	// Subtract vacation days, but don't go below 0
-	workingDays -= vacationDays
-	if workingDays < 0 {
-		workingDays = 0
+// calculateDeprecated is Calculate for the deprecated functions. Without
+// holidays, the country does not matter and a period outside MinYear-MaxYear
+// is moved into it by whole 400-year cycles of the Gregorian calendar, which
+// repeat the same weekdays.
+func calculateDeprecated(start, end time.Time, opts Options) Result {
+	if !opts.ExcludeHolidays {
+		opts.Country = ""
+		years := 0
+		for start.Year()+years < MinYear {
+			years += 400
+		}
+		for end.Year()+years > MaxYear {
+			years -= 400
+		}
+		start, end = start.AddDate(years, 0, 0), end.AddDate(years, 0, 0)
+		if !opts.Today.IsZero() {
+			opts.Today = opts.Today.AddDate(years, 0, 0)
+		}
 	}
-
-	return workingDays
+	result, _ := Calculate(start, end, opts)
+	return result
 }
@@ -1,7 +1,9 @@
 package calculator
 
 import (
+	"errors"
 	"fmt"
+	"github.com/honzahovorka/billme/internal/holidays"
 	"testing"
 	"time"
 )
@@ -255,3 +257,371 @@ func TestCountWorkingDaysWithHolidaysAndVacation(t *testing.T) {
 		t.Errorf("July 2024 with holidays and 3 vacation days: expected %d, got %d", expected, result)
 	}
 }
+
+func TestSplitWorkingDays(t *testing.T) {
+	tests := []struct {
+		name              string
+		today             time.Time
+		excludeHolidays   bool
+		vacationDays      int
+		expectedElapsed   int
+		expectedRemaining int
+	}{
+		{"Mid-month", time.Date(2024, 7, 15, 9, 30, 0, 0, time.UTC), false, 0, 10, 13},
+		{"Mid-month with holidays", time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), true, 0, 9, 13},
+		{"First day", time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), false, 0, 0, 23},
+		{"Last day", time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC), false, 0, 22, 1},
+		{"Month already over", time.Date(2024, 8, 5, 0, 0, 0, 0, time.UTC), true, 0, 22, 0},
+		{"Month not started", time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC), false, 0, 0, 23},
+		{"Vacation from remaining", time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), false, 3, 10, 10},
+		{"Vacation clamped", time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC), false, 3, 22, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elapsed, remaining := SplitWorkingDays(7, 2024, tt.today, "CZ", tt.excludeHolidays, tt.vacationDays)
+			if elapsed != tt.expectedElapsed || remaining != tt.expectedRemaining {
+				t.Errorf("SplitWorkingDays() = %d elapsed, %d remaining; want %d, %d",
+					elapsed, remaining, tt.expectedElapsed, tt.expectedRemaining)
+			}
+		})
+	}
+}
+
+func TestCountWorkingDaysInRange(t *testing.T) {
+	tests := []struct {
+		name            string
+		month, year     int
+		endMonth        int
+		endYear         int
+		excludeHolidays bool
+		vacationDays    int
+		expected        int
+	}{
+		{"Single month", 7, 2024, 7, 2024, true, 0, 22},
+		{"Quarter", 7, 2024, 9, 2024, true, 0, 65},
+		{"Across years", 11, 2024, 1, 2025, true, 0, 62},
+		{"Vacation taken once", 7, 2024, 9, 2024, true, 10, 55},
+		{"Vacation clamped", 7, 2024, 8, 2024, false, 100, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CountWorkingDaysInRange(tt.month, tt.year, tt.endMonth, tt.endYear, "CZ", tt.excludeHolidays, tt.vacationDays)
+			if result != tt.expected {
+				t.Errorf("CountWorkingDaysInRange() = %d; want %d", result, tt.expected)
+			}
+		})
+	}
+}
+
+type companyHolidays struct{}
+
+func (companyHolidays) GetHolidays(year int) []holidays.Holiday {
+	return []holidays.Holiday{{Name: "Company day", Date: date(year, 7, 10)}}
+}
+
+func (c companyHolidays) GetRegionalHolidays(year int, region string) []holidays.Holiday {
+	list := c.GetHolidays(year)
+	if region == "prague" {
+		list = append(list, holidays.Holiday{Name: "Prague day", Date: date(year, 7, 11)})
+	}
+	return list
+}
+
+func TestCalculate(t *testing.T) {
+	start, end := Month(7, 2024)
+
+	tests := []struct {
+		name        string
+		opts        Options
+		workingDays int
+		holidays    int
+		billable    float64
+	}{
+		{"Zero options", Options{}, 23, 0, 23},
+		{"Country", Options{Country: "CZ", ExcludeHolidays: true}, 22, 1, 22},
+		{"Four-day week", Options{WorkWeek: 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday}, 19, 0, 19},
+		{"Custom provider", Options{Provider: companyHolidays{}, ExcludeHolidays: true}, 22, 1, 22},
+		{"Custom provider region", Options{Provider: companyHolidays{}, Region: "prague", ExcludeHolidays: true}, 21, 2, 21},
+		{"Provider over country", Options{Country: "CZ", Provider: companyHolidays{}, ExcludeHolidays: true}, 22, 1, 22},
+		{"Leave and vacation days", Options{Leave: []Leave{{Date: date(2024, 7, 1)}, {Date: date(2024, 7, 2), Fraction: 0.25}}, VacationDays: 1}, 23, 0, 20.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Calculate(start, end, tt.opts)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if result.WorkingDays != tt.workingDays || result.Holidays != tt.holidays || result.Billable != tt.billable {
+				t.Errorf("Calculate() = %d working, %d holidays, %v billable; want %d, %d, %v",
+					result.WorkingDays, result.Holidays, result.Billable, tt.workingDays, tt.holidays, tt.billable)
+			}
+		})
+	}
+}
+
+func TestCalculateHolidayTypes(t *testing.T) {
+	// May 2024 in Bavaria: three public holidays and Corpus Christi, a
+	// regional one. August 2024: the Augsburg Peace Festival and Assumption Day,
+	// both optional in Bavaria.
+	tests := []struct {
+		name     string
+		month    int
+		opts     Options
+		holidays int
+	}{
+		{"Regional by default", 5, Options{Country: "DE", Region: "BY", ExcludeHolidays: true}, 4},
+		{"Regional left out", 5, Options{Country: "DE", Region: "BY", ExcludeHolidays: true, HolidayTypes: holidays.TypesOf(holidays.Public)}, 3},
+		{"Optional kept by default", 8, Options{Country: "DE", Region: "BY", ExcludeHolidays: true}, 0},
+		{"Optional excluded", 8, Options{Country: "DE", Region: "de-by", ExcludeHolidays: true, HolidayTypes: holidays.AllHolidayTypes}, 2},
+		{"Types without ExcludeHolidays", 5, Options{Country: "DE", Region: "BY", HolidayTypes: holidays.AllHolidayTypes}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CalculateMonth(tt.month, 2024, tt.opts)
+			if err != nil {
+				t.Fatalf("CalculateMonth() error = %v", err)
+			}
+			if result.Holidays != tt.holidays {
+				t.Errorf("CalculateMonth() excluded %d holidays; want %d", result.Holidays, tt.holidays)
+			}
+		})
+	}
+}
+
+func TestCalculateSpecialWorkingDays(t *testing.T) {
+	// February 2024 in China: the Spring Festival break from the 10th to the
+	// 17th, made up on Sundays the 4th and 18th. October 2026: the National
+	// Day break from the 1st to the 7th, made up on Saturday the 10th.
+	tests := []struct {
+		name        string
+		year, month int
+		opts        Options
+		workingDays int
+		holidays    int
+	}{
+		{"Spring Festival", 2024, 2, Options{Country: "CN", ExcludeHolidays: true}, 18, 5},
+		{"National Day", 2024, 10, Options{Country: "CN", ExcludeHolidays: true}, 19, 5},
+		{"National Day 2026", 2026, 10, Options{Country: "CN", ExcludeHolidays: true}, 18, 5},
+		{"Holidays included", 2024, 2, Options{Country: "CN"}, 21, 0},
+		{"Provider without working days", 2024, 2, Options{Country: "CZ", ExcludeHolidays: true}, 21, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CalculateMonth(tt.month, tt.year, tt.opts)
+			if err != nil {
+				t.Fatalf("CalculateMonth() error = %v", err)
+			}
+			if result.WorkingDays != tt.workingDays || result.Holidays != tt.holidays {
+				t.Errorf("CalculateMonth() = %d working days, %d holidays; want %d, %d", result.WorkingDays, result.Holidays, tt.workingDays, tt.holidays)
+			}
+		})
+	}
+
+	result, _ := CalculateMonth(2, 2024, Options{Country: "CN", ExcludeHolidays: true})
+	sunday := result.Days[3]
+	if sunday.Kind != Workday || sunday.Weight != 1 || sunday.WorkingDay == nil || sunday.WorkingDay.ID != "spring-festival-working-day" {
+		t.Errorf("February 4 = %v %v %+v; want a Spring Festival working day", sunday.Kind, sunday.Weight, sunday.WorkingDay)
+	}
+}
+
+func TestCalculateCombinedCountries(t *testing.T) {
+	// May 2024: Labour Day in both countries, Liberation Day in Czechia and
+	// Ascension Day, Whit Monday and Corpus Christi in Bavaria.
+	tests := []struct {
+		name     string
+		opts     Options
+		holidays int
+	}{
+		{"Czechia", Options{Country: "CZ", ExcludeHolidays: true}, 2},
+		{"Union", Options{Country: "CZ+DE-BY", ExcludeHolidays: true}, 5},
+		{"Intersection", Options{Country: "cz+de-by", Intersect: true, ExcludeHolidays: true}, 1},
+		{"Single region", Options{Country: "DE-BY", ExcludeHolidays: true}, 4},
+		{"Union of public holidays", Options{Country: "CZ+DE-BY", ExcludeHolidays: true, HolidayTypes: holidays.TypesOf(holidays.Public)}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CalculateMonth(5, 2024, tt.opts)
+			if err != nil {
+				t.Fatalf("CalculateMonth() error = %v", err)
+			}
+			if result.Holidays != tt.holidays {
+				t.Errorf("CalculateMonth() excluded %d holidays; want %d", result.Holidays, tt.holidays)
+			}
+		})
+	}
+
+	result, _ := CalculateMonth(5, 2024, Options{Country: "CZ+DE-BY", ExcludeHolidays: true})
+	if day := result.Days[29]; day.Kind != Holiday || day.Holidays[0].Country != "DE-BY" {
+		t.Errorf("May 30 = %v %+v; want Corpus Christi attributed to DE-BY", day.Kind, day.Holidays)
+	}
+}
+
+func TestLookupProviderErrors(t *testing.T) {
+	tests := []struct {
+		country string
+		target  any
+	}{
+		{"CZ+XX", new(*CountryError)},
+		{"CZ+DE-XX", new(*RegionError)},
+		{"CZ-PHA", new(*RegionError)},
+	}
+
+	for _, tt := range tests {
+		if _, err := LookupProvider(tt.country); !errors.As(err, tt.target) {
+			t.Errorf("LookupProvider(%s) error = %v; want %T", tt.country, err, tt.target)
+		}
+	}
+}
+
+func TestResolveProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		country   string
+		region    string
+		intersect bool
+		holidays  int // days in May 2024
+		target    any
+	}{
+		{"Country", "CZ", "", false, 2, nil},
+		{"Region", "DE", "BY", false, 4, nil},
+		{"Union", "CZ+DE-BY", "", false, 5, nil},
+		{"Intersection", "CZ+DE-BY", "", true, 1, nil},
+		{"Unknown country", "XX", "", false, 0, new(*CountryError)},
+		{"Unknown region", "DE", "XX", false, 0, new(*RegionError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := ResolveProvider(tt.country, tt.region, tt.intersect, 0)
+			if tt.target != nil {
+				if !errors.As(err, tt.target) {
+					t.Errorf("ResolveProvider() error = %v; want %T", err, tt.target)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveProvider() error = %v", err)
+			}
+			dates := map[time.Time]bool{}
+			for _, holiday := range holidays.ForRegion(provider, 2024, tt.region) {
+				if holiday.Date.Month() == time.May && holidays.DefaultHolidayTypes.Has(holiday.Type) {
+					dates[holiday.Date] = true
+				}
+			}
+			if len(dates) != tt.holidays {
+				t.Errorf("ResolveProvider() has holidays on %d days of May 2024; want %d", len(dates), tt.holidays)
+			}
+		})
+	}
+}
+
+func TestRegionError(t *testing.T) {
+	err := CheckRegion(&holidays.GermanHolidayProvider{}, "de", "XX")
+	var region *RegionError
+	if !errors.As(err, &region) || region.Country != "DE" || len(region.Regions) != 16 {
+		t.Errorf("CheckRegion() error = %v; want a *RegionError listing 16 Länder", err)
+	}
+	if err := CheckRegion(companyHolidays{}, "", "anywhere"); err != nil {
+		t.Errorf("CheckRegion() error = %v for a provider that does not list regions", err)
+	}
+}
+
+func TestCalculateAcrossYears(t *testing.T) {
+	result, err := Calculate(date(2024, 12, 30), date(2025, 1, 2), Options{Country: "CZ", ExcludeHolidays: true})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if len(result.Days) != 4 || result.Billable != 3 {
+		t.Errorf("Calculate() = %d days, %v billable; want 4, 3", len(result.Days), result.Billable)
+	}
+	if result.Days[2].Holiday != "Nový rok" {
+		t.Errorf("January 1 holiday = %q; want Nový rok", result.Days[2].Holiday)
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		month  int
+		year   int
+		opts   Options
+		target any
+	}{
+		{"Month 13", 13, 2024, Options{}, new(*MonthError)},
+		{"Month 0", 0, 2024, Options{}, new(*MonthError)},
+		{"Year before Easter algorithm", 7, 1582, Options{}, new(*YearError)},
+		{"Year after Easter algorithm", 7, 4100, Options{}, new(*YearError)},
+		{"Unknown country", 7, 2024, Options{Country: "XX"}, new(*CountryError)},
+		{"Region of a country without regions", 7, 2024, Options{Country: "CZ", Region: "PHA"}, new(*RegionError)},
+		{"Unknown region", 7, 2024, Options{Country: "DE", Region: "XX"}, new(*RegionError)},
+		{"Negative vacation", 7, 2024, Options{VacationDays: -1}, new(*VacationError)},
+		{"Vacation exceeds working days", 7, 2024, Options{VacationDays: 24}, new(*ExcessVacationError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CalculateMonth(tt.month, tt.year, tt.opts)
+			if !errors.As(err, tt.target) {
+				t.Errorf("CalculateMonth() error = %v; want %T", err, tt.target)
+			}
+		})
+	}
+}
+
+func TestCalculateExcessVacationResult(t *testing.T) {
+	result, err := CalculateMonth(7, 2024, Options{VacationDays: 30})
+	var excess *ExcessVacationError
+	if !errors.As(err, &excess) || excess.VacationDays != 30 || excess.WorkingDays != 23 {
+		t.Fatalf("CalculateMonth() error = %v; want 30 vacation days over 23 working days", err)
+	}
+	if result.Billable != 0 || len(result.Days) != 31 {
+		t.Errorf("CalculateMonth() = %v billable, %d days; want a result billing 0", result.Billable, len(result.Days))
+	}
+}
+
+func TestCalculatePeriodError(t *testing.T) {
+	_, err := Calculate(date(2024, 7, 10), date(2024, 7, 1), Options{})
+	var period *PeriodError
+	if !errors.As(err, &period) {
+		t.Errorf("Calculate() error = %v; want a *PeriodError", err)
+	}
+}
+
+func TestDeprecatedOutsideHolidayYears(t *testing.T) {
+	tests := []struct {
+		name            string
+		month, year     int
+		excludeHolidays bool
+		expected        int
+	}{
+		// July 2024 has 23 weekdays, as has July every 400 years before and after.
+		{"Before the holiday years", 7, 1224, false, 23},
+		{"After the holiday years", 7, 4424, false, 23},
+		{"Holidays excluded", 7, 1224, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountWorkingDaysWithHolidays(tt.month, tt.year, "CZ", tt.excludeHolidays); got != tt.expected {
+				t.Errorf("CountWorkingDaysWithHolidays(%d, %d) = %d; want %d", tt.month, tt.year, got, tt.expected)
+			}
+		})
+	}
+
+	elapsed, remaining := SplitWorkingDays(7, 1224, date(1224, 7, 15), "CZ", false, 0)
+	if elapsed != 10 || remaining != 13 {
+		t.Errorf("SplitWorkingDays(7, 1224) = %d elapsed, %d remaining; want 10, 13", elapsed, remaining)
+	}
+}
+
+func TestDeprecatedInvalidMonth(t *testing.T) {
+	if got := CountWorkingDaysWithHolidaysAndVacation(13, 2024, "CZ", true, 0); got != 0 {
+		t.Errorf("CountWorkingDaysWithHolidaysAndVacation(13, 2024) = %d; want 0 instead of January 2025", got)
+	}
+}
@@ -0,0 +1,151 @@
+package calculator
+
+import (
+	"github.com/honzahovorka/billme/internal/holidays"
+	"time"
+)
+
+// Calendar answers business-day questions for dates in any year, loading
+// holidays from the provider as they are needed.
+type Calendar struct {
+	WorkWeek WorkWeek
+	// Region narrows the holidays to a subdivision of the country.
+	Region string
+	// HolidayTypes are the types of holiday that are days off; zero means
+	// holidays.DefaultHolidayTypes.
+	HolidayTypes holidays.HolidayTypes
+
+	provider    holidays.HolidayProvider
+	years       map[int][]holidays.Holiday
+	workingDays map[int][]holidays.Holiday
+}
+
+// NewCalendar returns a calendar with a Monday to Friday work week and the
+// holidays of provider. A nil provider makes every weekday a business day.
+func NewCalendar(provider holidays.HolidayProvider) *Calendar {
+	return &Calendar{WorkWeek: DefaultWorkWeek, provider: provider, years: map[int][]holidays.Holiday{}, workingDays: map[int][]holidays.Holiday{}}
+}
+
+// Holiday returns the holiday falling on day that is a day off, if any.
+func (c *Calendar) Holiday(day time.Time) (holidays.Holiday, bool) {
+	if c.provider == nil {
+		return holidays.Holiday{}, false
+	}
+
+	list, ok := c.years[day.Year()]
+	if !ok {
+		list = holidays.ForRegion(c.provider, day.Year(), c.Region)
+		c.years[day.Year()] = list
+	}
+
+	types := c.HolidayTypes
+	if types == 0 {
+		types = holidays.DefaultHolidayTypes
+	}
+	for _, holiday := range list {
+		if sameDay(holiday.Date, day) && types.Has(holiday.Type) {
+			return holiday, true
+		}
+	}
+	return holidays.Holiday{}, false
+}
+
+// WorkingDay returns the special working day falling on day, if any: a
+// weekend day worked to make up for a day off.
+func (c *Calendar) WorkingDay(day time.Time) (holidays.Holiday, bool) {
+	if c.provider == nil {
+		return holidays.Holiday{}, false
+	}
+
+	list, ok := c.workingDays[day.Year()]
+	if !ok {
+		list = holidays.WorkingDays(c.provider, day.Year())
+		c.workingDays[day.Year()] = list
+	}
+	for _, workingDay := range list {
+		if sameDay(workingDay.Date, day) {
+			return workingDay, true
+		}
+	}
+	return holidays.Holiday{}, false
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+// IsWorkingDay reports whether day is in the work week or a special working
+// day, and not a holiday.
+func (c *Calendar) IsWorkingDay(day time.Time) bool {
+	if _, working := c.WorkingDay(day); !c.WorkWeek.Has(day.Weekday()) && !working {
+		return false
+	}
+	_, holiday := c.Holiday(day)
+	return !holiday
+}
+
+// NextWorkingDay returns day itself if it is a working day, otherwise the
+// first working day after it.
+func (c *Calendar) NextWorkingDay(day time.Time) time.Time {
+	for !c.IsWorkingDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// AddWorkingDays returns the date n working days after day, or before it
+// when n is negative, not counting day itself.
+func (c *Calendar) AddWorkingDays(day time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		day = day.AddDate(0, 0, step)
+		if c.IsWorkingDay(day) {
+			n--
+		}
+	}
+	return day
+}
+
+// NthWorkingDay returns the nth working day of the month, counting from the
+// end when n is negative (-1 is the last working day). The second result
+// is false when the month has fewer working days.
+func (c *Calendar) NthWorkingDay(month, year, n int) (time.Time, bool) {
+	firstDay := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, -1)
+
+	day, step := firstDay, 1
+	if n < 0 {
+		day, step, n = lastDay, -1, -n
+	}
+	if n == 0 {
+		return time.Time{}, false
+	}
+
+	for ; !day.Before(firstDay) && !day.After(lastDay); day = day.AddDate(0, 0, step) {
+		if c.IsWorkingDay(day) {
+			n--
+			if n == 0 {
+				return day, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// LastWorkingDay returns the last working day of the month.
+func (c *Calendar) LastWorkingDay(month, year int) (time.Time, bool) {
+	return c.NthWorkingDay(month, year, -1)
+}
+
+// DueDate computes when an invoice issued on issued is payable within net
+// days. Business terms count working days; calendar terms count every day
+// and roll forward when the due date lands on a weekend or holiday.
+func DueDate(c *Calendar, issued time.Time, net int, business bool) time.Time {
+	if business {
+		return c.AddWorkingDays(issued, net)
+	}
+	return c.NextWorkingDay(issued.AddDate(0, 0, net))
+}
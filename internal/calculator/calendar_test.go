@@ -0,0 +1,196 @@
+package calculator
+
+import (
+	"github.com/honzahovorka/billme/internal/holidays"
+	"testing"
+	"time"
+)
+
+func date(year, month, day int) time.Time {
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalendarIsWorkingDay(t *testing.T) {
+	calendar := NewCalendar(&holidays.CzechHolidayProvider{})
+
+	tests := []struct {
+		name     string
+		day      time.Time
+		expected bool
+	}{
+		{"Regular Monday", date(2024, 7, 1), true},
+		{"Saturday", date(2024, 7, 6), false},
+		{"Sunday", date(2024, 7, 7), false},
+		{"Christmas Eve on Tuesday", date(2024, 12, 24), false},
+		{"Easter Monday 2025", date(2025, 4, 21), false},
+		{"Day after Easter Monday", date(2025, 4, 22), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := calendar.IsWorkingDay(tt.day); result != tt.expected {
+				t.Errorf("IsWorkingDay(%s) = %v; want %v", tt.day.Format("2006-01-02"), result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCalendarWithoutProvider(t *testing.T) {
+	calendar := NewCalendar(nil)
+
+	if !calendar.IsWorkingDay(date(2024, 12, 24)) {
+		t.Error("Without a provider, Christmas Eve on Tuesday should be a working day")
+	}
+	if _, ok := calendar.Holiday(date(2024, 12, 24)); ok {
+		t.Error("Without a provider, there should be no holidays")
+	}
+}
+
+func TestCalendarHoliday(t *testing.T) {
+	calendar := NewCalendar(&holidays.CzechHolidayProvider{})
+
+	holiday, ok := calendar.Holiday(date(2024, 7, 5))
+	if !ok || holiday.Name != "Den slovanských věrozvěstů Cyrila a Metoděje" {
+		t.Errorf("Holiday(2024-07-05) = %q, %v; want Cyril and Methodius Day", holiday.Name, ok)
+	}
+}
+
+func TestCalendarRegion(t *testing.T) {
+	calendar := NewCalendar(&holidays.GermanHolidayProvider{})
+	corpusChristi := date(2024, 5, 30)
+
+	if !calendar.IsWorkingDay(corpusChristi) {
+		t.Error("Corpus Christi should be a working day without a region")
+	}
+	calendar.Region = "BY"
+	calendar.years = map[int][]holidays.Holiday{}
+	if calendar.IsWorkingDay(corpusChristi) {
+		t.Error("Corpus Christi should be a holiday in Bavaria")
+	}
+	calendar.HolidayTypes = holidays.TypesOf(holidays.Public)
+	if !calendar.IsWorkingDay(corpusChristi) {
+		t.Error("Corpus Christi should be a working day when regional holidays are not days off")
+	}
+}
+
+func TestCalendarWorkingDay(t *testing.T) {
+	calendar := NewCalendar(&holidays.ChineseHolidayProvider{})
+
+	if workingDay, ok := calendar.WorkingDay(date(2024, 2, 18)); !ok || workingDay.EnglishName != "Spring Festival (working day)" {
+		t.Errorf("WorkingDay() = %+v, %v; want the Spring Festival working day", workingDay, ok)
+	}
+	if !calendar.IsWorkingDay(date(2024, 2, 18)) {
+		t.Error("Sunday February 18, 2024 should be a working day in China")
+	}
+	if calendar.IsWorkingDay(date(2024, 2, 16)) {
+		t.Error("Friday February 16, 2024 should be a rest day in China")
+	}
+	// Friday February 9 plus one working day skips the break to Sunday the 18th.
+	if got := calendar.AddWorkingDays(date(2024, 2, 9), 1); !got.Equal(date(2024, 2, 18)) {
+		t.Errorf("AddWorkingDays() = %s; want 2024-02-18", got.Format("2006-01-02"))
+	}
+}
+
+func TestDueDate(t *testing.T) {
+	calendar := NewCalendar(&holidays.CzechHolidayProvider{})
+
+	tests := []struct {
+		name     string
+		issued   time.Time
+		net      int
+		business bool
+		expected time.Time
+	}{
+		{"14 calendar days", date(2024, 7, 31), 14, false, date(2024, 8, 14)},
+		{"14 business days", date(2024, 7, 31), 14, true, date(2024, 8, 20)},
+		{"Calendar due on a Saturday holiday rolls to Monday", date(2024, 7, 1), 5, false, date(2024, 7, 8)},
+		{"Calendar due on Christmas rolls past the holidays", date(2024, 12, 10), 14, false, date(2024, 12, 27)},
+		{"Business days across the new year", date(2024, 12, 20), 5, true, date(2025, 1, 2)},
+		{"Zero days on a working day", date(2024, 7, 31), 0, false, date(2024, 7, 31)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DueDate(calendar, tt.issued, tt.net, tt.business)
+			if !result.Equal(tt.expected) {
+				t.Errorf("DueDate(%s, %d, %v) = %s; want %s", tt.issued.Format("2006-01-02"), tt.net, tt.business,
+					result.Format("2006-01-02"), tt.expected.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestAddWorkingDays(t *testing.T) {
+	calendar := NewCalendar(&holidays.CzechHolidayProvider{})
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		n        int
+		expected time.Time
+	}{
+		{"10 after July 1", date(2024, 7, 1), 10, date(2024, 7, 16)},
+		{"Back over a weekend", date(2024, 7, 8), -1, date(2024, 7, 4)},
+		{"Back over Christmas", date(2024, 12, 27), -1, date(2024, 12, 23)},
+		{"Zero", date(2024, 7, 6), 0, date(2024, 7, 6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calendar.AddWorkingDays(tt.from, tt.n)
+			if !result.Equal(tt.expected) {
+				t.Errorf("AddWorkingDays(%s, %d) = %s; want %s", tt.from.Format("2006-01-02"), tt.n,
+					result.Format("2006-01-02"), tt.expected.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestNthWorkingDay(t *testing.T) {
+	calendar := NewCalendar(&holidays.CzechHolidayProvider{})
+
+	tests := []struct {
+		name     string
+		n        int
+		month    int
+		year     int
+		expected time.Time
+		ok       bool
+	}{
+		{"First of January skips New Year", 1, 1, 2024, date(2024, 1, 2), true},
+		{"Third of July", 3, 7, 2024, date(2024, 7, 3), true},
+		{"Last of December skips nothing", -1, 12, 2024, date(2024, 12, 31), true},
+		{"Last of August 2025 on Sunday", -1, 8, 2025, date(2025, 8, 29), true},
+		{"Second to last of December", -2, 12, 2024, date(2024, 12, 30), true},
+		{"Beyond the month", 25, 7, 2024, time.Time{}, false},
+		{"Zero", 0, 7, 2024, time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := calendar.NthWorkingDay(tt.month, tt.year, tt.n)
+			if ok != tt.ok || !result.Equal(tt.expected) {
+				t.Errorf("NthWorkingDay(%d, %d, %d) = %s, %v; want %s, %v", tt.month, tt.year, tt.n,
+					result.Format("2006-01-02"), ok, tt.expected.Format("2006-01-02"), tt.ok)
+			}
+		})
+	}
+
+	last, _ := calendar.LastWorkingDay(7, 2024)
+	if !last.Equal(date(2024, 7, 31)) {
+		t.Errorf("LastWorkingDay(7, 2024) = %s; want 2024-07-31", last.Format("2006-01-02"))
+	}
+}
+
+func TestCalendarWorkWeek(t *testing.T) {
+	calendar := NewCalendar(&holidays.CzechHolidayProvider{})
+	calendar.WorkWeek, _ = ParseWorkWeek("mon-thu")
+
+	if calendar.IsWorkingDay(date(2024, 7, 12)) {
+		t.Error("Friday should not be a working day in a Monday to Thursday week")
+	}
+	last, _ := calendar.LastWorkingDay(5, 2024)
+	if !last.Equal(date(2024, 5, 30)) {
+		t.Errorf("LastWorkingDay(5, 2024) with mon-thu = %s; want 2024-05-30", last.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,87 @@
+package calculator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MinYear and MaxYear bound the years Calculate accepts: the range in which
+// the Gregorian Easter algorithm behind the movable holidays is valid.
+const (
+	MinYear = 1583
+	MaxYear = 4099
+)
+
+// MonthError reports a month outside 1-12.
+type MonthError struct {
+	Month int
+}
+
+func (e *MonthError) Error() string {
+	return fmt.Sprintf("invalid month: %d (months are 1-12)", e.Month)
+}
+
+// YearError reports a year outside MinYear-MaxYear.
+type YearError struct {
+	Year int
+}
+
+func (e *YearError) Error() string {
+	return fmt.Sprintf("year out of range: %d (supported %d-%d)", e.Year, MinYear, MaxYear)
+}
+
+// PeriodError reports a period that ends before it starts.
+type PeriodError struct {
+	Start, End time.Time
+}
+
+func (e *PeriodError) Error() string {
+	return fmt.Sprintf("period ends before it starts: %s..%s", e.Start.Format(time.DateOnly), e.End.Format(time.DateOnly))
+}
+
+// CountryError reports a country without holiday data.
+type CountryError struct {
+	Country string
+}
+
+func (e *CountryError) Error() string {
+	return fmt.Sprintf("unknown country: %s", e.Country)
+}
+
+// RegionError reports a region the country has no holidays for. Regions
+// lists the known ones; it is empty when the country has no regional
+// holidays at all.
+type RegionError struct {
+	Country string
+	Region  string
+	Regions []string
+}
+
+func (e *RegionError) Error() string {
+	if len(e.Regions) == 0 {
+		return fmt.Sprintf("%s has no regional holidays", e.Country)
+	}
+	return fmt.Sprintf("unknown region of %s: %s (use %s)", e.Country, e.Region, strings.Join(e.Regions, ", "))
+}
+
+// VacationError reports a negative number of vacation days.
+type VacationError struct {
+	Days int
+}
+
+func (e *VacationError) Error() string {
+	return fmt.Sprintf("invalid vacation days: %d (must not be negative)", e.Days)
+}
+
+// ExcessVacationError reports more vacation than working days. Calculate
+// returns it together with a valid result billing zero days, so callers can
+// treat it as a warning.
+type ExcessVacationError struct {
+	VacationDays float64
+	WorkingDays  float64
+}
+
+func (e *ExcessVacationError) Error() string {
+	return fmt.Sprintf("vacation days (%v) exceed the working days (%v); billing 0", e.VacationDays, e.WorkingDays)
+}
@@ -0,0 +1,142 @@
+package calculator
+
+import (
+	"sort"
+	"time"
+)
+
+// A Break is a run of consecutive days off made by taking vacation on the
+// working days between weekends and holidays.
+type Break struct {
+	Start    time.Time
+	End      time.Time
+	Vacation []time.Time // the working days taken off
+}
+
+// Days returns the length of the break in days.
+func (b Break) Days() int {
+	return int(b.End.Sub(b.Start).Hours()/24) + 1
+}
+
+const (
+	// maxBreakVacation is the most vacation spent on a single break: two
+	// working weeks.
+	maxBreakVacation = 10
+	// longBreak is the length of a break worth taking without a holiday in
+	// it: a working week between two weekends.
+	longBreak = 9
+)
+
+// PlanVacation suggests breaks in year for up to days vacation days, the
+// ones that give the most days off per vacation day first: bridges to
+// holidays, then weeks spread over the year, and then long weekends with
+// the days left. The breaks are in date order; their vacation adds up to
+// less than days only when no break fits the rest.
+func (c *Calendar) PlanVacation(year, days int) []Break {
+	type run struct {
+		start, end time.Time
+		holiday    bool
+	}
+	var runs []run
+	last := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	for day := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if c.IsWorkingDay(day) {
+			continue
+		}
+		_, holiday := c.Holiday(day)
+		if n := len(runs); n > 0 && runs[n-1].end.Equal(day.AddDate(0, 0, -1)) {
+			runs[n-1].end = day
+			runs[n-1].holiday = runs[n-1].holiday || holiday
+		} else {
+			runs = append(runs, run{day, day, holiday})
+		}
+	}
+
+	// A candidate bridges the runs of days off from start to end with
+	// vacation on every working day between them; a long weekend adds a day
+	// before or after a single run of two days or more.
+	type candidate struct {
+		start, end time.Time
+		cost, days int
+		holiday    bool
+	}
+	span := func(start, end time.Time, cost int, holiday bool) candidate {
+		return candidate{start, end, cost, int(end.Sub(start).Hours()/24) + 1, holiday}
+	}
+	var bridges, weekends []candidate
+	for i := range runs {
+		cost, holiday := 0, runs[i].holiday
+		for j := i + 1; j < len(runs); j++ {
+			cost += int(runs[j].start.Sub(runs[j-1].end).Hours()/24) - 1
+			holiday = holiday || runs[j].holiday
+			if cost > maxBreakVacation {
+				break
+			}
+			bridges = append(bridges, span(runs[i].start, runs[j].end, cost, holiday))
+		}
+		if runs[i].start.Equal(runs[i].end) {
+			continue
+		}
+		if before := runs[i].start.AddDate(0, 0, -1); before.Year() == year {
+			weekends = append(weekends, span(before, runs[i].end, 1, runs[i].holiday))
+		}
+		if after := runs[i].end.AddDate(0, 0, 1); after.Year() == year {
+			weekends = append(weekends, span(runs[i].start, after, 1, runs[i].holiday))
+		}
+	}
+
+	// Breaks are kept apart by at least a working day, so that each stays
+	// a break of its own.
+	var chosen []candidate
+	gap := func(a, b candidate) int {
+		return int(max(a.start.Sub(b.end), b.start.Sub(a.end)).Hours()/24) - 1
+	}
+	distance := func(cand candidate) int {
+		nearest := 366
+		for _, other := range chosen {
+			nearest = min(nearest, gap(cand, other))
+		}
+		return nearest
+	}
+	better := func(a, b candidate) bool {
+		if a.days*b.cost != b.days*a.cost {
+			return a.days*b.cost > b.days*a.cost
+		}
+		if a.days != b.days {
+			return a.days > b.days
+		}
+		return distance(a) > distance(b)
+	}
+	pick := func(candidates []candidate, worthIt func(candidate) bool) {
+		for {
+			best := -1
+			for i, cand := range candidates {
+				if cand.cost > days || !worthIt(cand) || distance(cand) < 1 {
+					continue
+				}
+				if best < 0 || better(cand, candidates[best]) {
+					best = i
+				}
+			}
+			if best < 0 {
+				return
+			}
+			days -= candidates[best].cost
+			chosen = append(chosen, candidates[best])
+		}
+	}
+	pick(bridges, func(cand candidate) bool { return cand.holiday || cand.days >= longBreak })
+	pick(append(bridges, weekends...), func(candidate) bool { return true })
+
+	sort.Slice(chosen, func(i, j int) bool { return chosen[i].start.Before(chosen[j].start) })
+	breaks := make([]Break, len(chosen))
+	for i, cand := range chosen {
+		breaks[i] = Break{Start: cand.start, End: cand.end}
+		for day := breaks[i].Start; !day.After(breaks[i].End); day = day.AddDate(0, 0, 1) {
+			if c.IsWorkingDay(day) {
+				breaks[i].Vacation = append(breaks[i].Vacation, day)
+			}
+		}
+	}
+	return breaks
+}
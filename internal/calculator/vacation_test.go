@@ -0,0 +1,89 @@
+package calculator
+
+import (
+	"github.com/honzahovorka/billme/internal/holidays"
+	"testing"
+)
+
+func TestPlanVacation(t *testing.T) {
+	calendar := NewCalendar(&holidays.CzechHolidayProvider{})
+
+	// 2025: bridges to New Year, Labour Day, Liberation Day, Statehood Day
+	// and Christmas, then weeks around Easter and November 17, and two weeks
+	// without holidays far from the others.
+	expected := []struct {
+		start, end string
+		vacation   int
+	}{
+		{"2025-01-01", "2025-01-05", 2},
+		{"2025-02-22", "2025-03-02", 5},
+		{"2025-04-19", "2025-04-27", 4},
+		{"2025-05-01", "2025-05-04", 1},
+		{"2025-05-08", "2025-05-11", 1},
+		{"2025-07-26", "2025-08-03", 5},
+		{"2025-10-25", "2025-10-28", 1},
+		{"2025-11-15", "2025-11-23", 4},
+		{"2025-12-20", "2025-12-28", 2},
+	}
+
+	breaks := calendar.PlanVacation(2025, 25)
+	if len(breaks) != len(expected) {
+		t.Fatalf("PlanVacation() returned %d breaks; want %d", len(breaks), len(expected))
+	}
+	for i, b := range breaks {
+		start, end := b.Start.Format("2006-01-02"), b.End.Format("2006-01-02")
+		if start != expected[i].start || end != expected[i].end || len(b.Vacation) != expected[i].vacation {
+			t.Errorf("break %d = %s to %s with %d vacation days; want %s to %s with %d",
+				i, start, end, len(b.Vacation), expected[i].start, expected[i].end, expected[i].vacation)
+		}
+	}
+}
+
+func TestPlanVacationBudget(t *testing.T) {
+	calendar := NewCalendar(&holidays.CzechHolidayProvider{})
+
+	tests := []struct {
+		days     int
+		expected int
+	}{
+		{0, 0},
+		{1, 1},
+		{3, 3},
+		{25, 25},
+		{60, 60},
+		{400, 114}, // no break fits the rest
+	}
+
+	for _, tt := range tests {
+		breaks := calendar.PlanVacation(2024, tt.days)
+		spent := 0
+		for i, b := range breaks {
+			spent += len(b.Vacation)
+			if b.Days() < 3 || len(b.Vacation) == 0 || len(b.Vacation) > maxBreakVacation {
+				t.Errorf("PlanVacation(%d) break %s to %s has %d vacation days", tt.days, b.Start.Format("2006-01-02"), b.End.Format("2006-01-02"), len(b.Vacation))
+			}
+			for _, day := range b.Vacation {
+				if !calendar.IsWorkingDay(day) {
+					t.Errorf("PlanVacation(%d) takes %s off, not a working day", tt.days, day.Format("2006-01-02"))
+				}
+			}
+			if i > 0 && !breaks[i-1].End.AddDate(0, 0, 1).Before(b.Start) {
+				t.Errorf("PlanVacation(%d) breaks %d and %d overlap or touch", tt.days, i-1, i)
+			}
+		}
+		if spent != tt.expected {
+			t.Errorf("PlanVacation(%d) spends %d days; want %d", tt.days, spent, tt.expected)
+		}
+	}
+}
+
+func TestPlanVacationWorkWeek(t *testing.T) {
+	calendar := NewCalendar(nil)
+	calendar.WorkWeek, _ = ParseWorkWeek("mon-thu")
+
+	// With Fridays off a week of vacation makes ten days off.
+	breaks := calendar.PlanVacation(2024, 4)
+	if len(breaks) != 1 || breaks[0].Days() != 10 || breaks[0].Start.Weekday().String() != "Friday" {
+		t.Errorf("PlanVacation() = %+v; want a break from Friday to Sunday a week later", breaks)
+	}
+}
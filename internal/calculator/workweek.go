@@ -0,0 +1,74 @@
+package calculator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WorkWeek is the set of weekdays that are normally worked, one bit per
+// time.Weekday.
+type WorkWeek uint8
+
+// DefaultWorkWeek is Monday to Friday.
+const DefaultWorkWeek = WorkWeek(1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday)
+
+// Has reports whether weekday is a working day of the week.
+func (w WorkWeek) Has(weekday time.Weekday) bool {
+	return w&(1<<weekday) != 0
+}
+
+func (w WorkWeek) String() string {
+	var days []string
+	for i := 0; i < 7; i++ {
+		weekday := time.Weekday((int(time.Monday) + i) % 7)
+		if w.Has(weekday) {
+			days = append(days, weekdayNames[weekday])
+		}
+	}
+	return strings.Join(days, ",")
+}
+
+var weekdayNames = [7]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, short := range weekdayNames {
+		if len(name) >= 2 && strings.HasPrefix(short, name[:min(len(name), 3)]) {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday: %s", name)
+}
+
+// ParseWorkWeek parses a list of weekdays and ranges such as "mon-fri",
+// "mon-thu" or "sun-thu,sat". Ranges may wrap around the end of the week.
+func ParseWorkWeek(value string) (WorkWeek, error) {
+	var week WorkWeek
+	for _, part := range strings.Split(value, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+
+		start, err := parseWeekday(from)
+		if err != nil {
+			return 0, err
+		}
+		end := start
+		if isRange {
+			if end, err = parseWeekday(to); err != nil {
+				return 0, err
+			}
+		}
+
+		for day := start; ; day = (day + 1) % 7 {
+			week |= 1 << day
+			if day == end {
+				break
+			}
+		}
+	}
+
+	if week == 0 {
+		return 0, fmt.Errorf("work week has no days: %s", value)
+	}
+	return week, nil
+}
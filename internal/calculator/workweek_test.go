@@ -0,0 +1,48 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWorkWeek(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"mon-fri", "mon,tue,wed,thu,fri"},
+		{"Mon-Thu", "mon,tue,wed,thu"},
+		{"sun-thu", "mon,tue,wed,thu,sun"},
+		{"fri-mon", "mon,fri,sat,sun"},
+		{"monday,wednesday,sa", "mon,wed,sat"},
+		{"tue", "tue"},
+	}
+
+	for _, tt := range tests {
+		week, err := ParseWorkWeek(tt.value)
+		if err != nil {
+			t.Errorf("ParseWorkWeek(%q) returned error: %v", tt.value, err)
+			continue
+		}
+		if week.String() != tt.expected {
+			t.Errorf("ParseWorkWeek(%q) = %s; want %s", tt.value, week, tt.expected)
+		}
+	}
+}
+
+func TestParseWorkWeekInvalid(t *testing.T) {
+	for _, value := range []string{"", "m", "mon-xyz", "funday", "mon,,fri"} {
+		if _, err := ParseWorkWeek(value); err == nil {
+			t.Errorf("ParseWorkWeek(%q) should return error", value)
+		}
+	}
+}
+
+func TestDefaultWorkWeek(t *testing.T) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		expected := weekday >= time.Monday && weekday <= time.Friday
+		if DefaultWorkWeek.Has(weekday) != expected {
+			t.Errorf("DefaultWorkWeek.Has(%s) = %v; want %v", weekday, !expected, expected)
+		}
+	}
+}
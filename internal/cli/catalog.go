@@ -0,0 +1,460 @@
+package cli
+
+import "github.com/honzahovorka/billme/internal/i18n"
+
+// tr translates user-facing text into the language chosen with --lang or
+// the locale environment.
+var tr = i18n.NewPrinter(i18n.English, catalog)
+
+// Printer returns the printer for the language of the last parsed command
+// line, for messages printed outside this package.
+func Printer() *i18n.Printer {
+	return tr
+}
+
+// detectLanguage selects the language of the locale environment, so that
+// errors found while parsing the command line are already translated.
+func detectLanguage() {
+	lang, _ := i18n.Detect("")
+	tr = i18n.NewPrinter(lang, catalog)
+}
+
+// setLanguage switches to the language given by --lang, if any.
+func setLanguage(value string) error {
+	if value == "" {
+		return nil
+	}
+	lang, err := i18n.Detect(value)
+	if err != nil {
+		return err
+	}
+	tr = i18n.NewPrinter(lang, catalog)
+	return nil
+}
+
+var catalog = i18n.Catalog{
+	i18n.English: {
+		Plural: map[string][]string{
+			"output.verbose":     {"%s: %d billable day 💸", "%s: %d billable days 💸"},
+			"output.kaching":     {"%d day = CHA-CHING! 🤑", "%d days = CHA-CHING! 🤑"},
+			"progress.elapsed":   {"%d of %d billable days elapsed", "%d of %d billable days elapsed"},
+			"progress.remaining": {"%d remaining", "%d remaining"},
+			"due.business":       {"%d business day", "%d business days"},
+			"due.calendar":       {"%d calendar day", "%d calendar days"},
+			"nth.fewer":          {"%[1]s has no working days", "%[1]s has fewer than %[3]d working days"},
+			"holidays.workdays":  {"%d of %d falls on a workday", "%d of %d fall on workdays"},
+			"plan.off":           {"%d day off", "%d days off"},
+			"plan.vacation":      {"%d vacation day", "%d vacation days"},
+			"plan.unused":        {"%d vacation day left over: no break fits it", "%d vacation days left over: no break fits them"},
+		},
+	},
+	i18n.Czech: {
+		Text: map[string]string{
+			mainHelp:      czechMainHelp,
+			usageHint:     czechUsageHint,
+			dueHelp:       czechDueHelp,
+			numberHelp:    czechNumberHelp,
+			workdaysHelp:  czechWorkdaysHelp,
+			holidaysHelp:  czechHolidaysHelp,
+			icsHelp:       czechICSHelp,
+			isWorkdayHelp: czechIsWorkdayHelp,
+			planHelp:      czechPlanHelp,
+
+			"Error: %v":                      "Chyba: %v",
+			"Warning: %v":                    "Upozornění: %v",
+			"Issued invoice number %s":       "Vystaveno číslo faktury %s",
+			"Voided %s":                      "Zneplatněno %s",
+			"Reissued %s":                    "Znovu vydáno %s",
+			"No invoice numbers issued yet.": "Zatím nebylo vystaveno žádné číslo faktury.",
+			"Audit trail:":                   "Auditní záznam:",
+
+			"%[1]s: %[3]s, %[4]s [%[5]s] %[6]d%% 💸": "V %[2]s %[3]s, %[4]s [%[5]s] %[6]d %% 💸",
+			"Issued %s + %s: due %s %s 📅":           "Vystaveno %s + %s: splatnost %s %s 📅",
+
+			"too many arguments":                                         "příliš mnoho argumentů",
+			"invalid month: %d (months are 1-12)":                        "neplatný měsíc: %d (měsíce jsou 1–12)",
+			"year out of range: %d (supported %d-%d)":                    "rok mimo rozsah: %d (podporováno %d–%d)",
+			"period ends before it starts: %s..%s":                       "období končí dřív, než začne: %s..%s",
+			"unknown country: %s":                                        "neznámá země: %s",
+			"invalid vacation days: %d (must not be negative)":           "neplatný počet dní dovolené: %d (nesmí být záporný)",
+			"vacation days (%v) exceed the working days (%v); billing 0": "dny dovolené (%v) přesahují pracovní dny (%v); fakturuje se 0",
+			"invalid month: %s":                                          "neplatný měsíc: %s",
+			"invalid year: %s":                                           "neplatný rok: %s",
+			"invalid date: %s":                                           "neplatné datum: %s",
+			"invalid due date: %s":                                       "neplatné datum splatnosti: %s",
+			"invalid issue date: %s":                                     "neplatné datum vystavení: %s",
+			"invalid today date: %s":                                     "neplatné dnešní datum: %s",
+			"invalid rate: %v":                                           "neplatná sazba: %v",
+			"invalid exchange rate: %v":                                  "neplatný kurz: %v",
+			"invalid payment terms: %d":                                  "neplatná splatnost: %d",
+			"invalid n: %s":                                              "neplatné pořadí: %s",
+			"invalid number of days: %s":                                 "neplatný počet dní: %s",
+			"invalid month: %s (months are 1-12)":                        "neplatný měsíc: %s (měsíce jsou 1–12)",
+			"invalid month: %s (did you mean %s?)":                       "neplatný měsíc: %s (neměli jste na mysli %s?)",
+			" or ":                                                       " nebo ",
+			"%s already includes the year":                               "%s už obsahuje rok",
+			"a month range is not supported here: %s":                    "rozsah měsíců tu není podporován: %s",
+			"range ends before it starts: %s (use YYYY-MM..YYYY-MM to span years)": "rozsah končí dřív, než začíná: %s (pro rozsah přes více let použijte YYYY-MM..YYYY-MM)",
+			"--iban is required for a QR payment code":                             "pro QR platbu je potřeba --iban",
+			"--rate is required for a QR payment code":                             "pro QR platbu je potřeba --rate",
+			"--rate is required for an ISDOC invoice":                              "pro fakturu ISDOC je potřeba --rate",
+			"--exchange-rate is required for an ISDOC invoice in %s":               "pro fakturu ISDOC v %s je potřeba --exchange-rate",
+			"--remaining and --elapsed cannot be combined":                         "--remaining a --elapsed nelze kombinovat",
+			"--remaining and --elapsed need a single month":                        "--remaining a --elapsed vyžadují jediný měsíc",
+			"missing action: next, void, reissue or list":                          "chybí akce: next, void, reissue nebo list",
+			"unknown action: %s":                                                   "neznámá akce: %s",
+			"usage: billme number %s <number> --reason <text>":                     "použití: billme number %s <číslo> --reason <text>",
+			"--reason is required to %s an invoice number":                         "pro akci %s je potřeba uvést --reason",
+			"usage: billme shift <YYYY-MM-DD> <±days>":                             "použití: billme shift <RRRR-MM-DD> <±dny>",
+			"usage: billme nth <n> [month] [year]":                                 "použití: billme nth <n> [měsíc] [rok]",
+
+			"invalid format: unknown field .%s (did you mean %s?)":                "neplatný formát: neznámé pole .%s (neměli jste na mysli %s?)",
+			"invalid format: unknown field .%s (available: %s)":                   "neplatný formát: neznámé pole .%s (k dispozici: %s)",
+			"invalid format: unknown function %s (available: %s)":                 "neplatný formát: neznámá funkce %s (k dispozici: %s)",
+			"invalid format at line %s: %s":                                       "neplatný formát na řádku %s: %s",
+			"unknown output format: %s (use markdown or html)":                    "neznámý formát výstupu: %s (použijte markdown nebo html)",
+			"--output cannot be combined with --format, --remaining or --elapsed": "--output nelze kombinovat s --format, --remaining ani --elapsed",
+			"vacation date %s is outside the billed period":                       "den dovolené %s je mimo fakturované období",
+			"range ends before it starts: %s":                                     "rozsah končí dřív, než začíná: %s",
+			"unknown output format: %s (use json, csv or ics)":                    "neznámý formát výstupu: %s (použijte json, csv nebo ics)",
+			"%s has no regional holidays":                                         "%s nemá regionální svátky",
+			"Public holidays %d (%s)":                                             "Státní svátky %d (%s)",
+			"workday":                                                             "pracovní den",
+			"Holidays and vacation (%s)":                                          "Svátky a dovolená (%s)",
+			"Vacation":                                                            "Dovolená",
+			"%s is a working day":                                                 "%s je pracovní den",
+			"%s is a working day: %s":                                             "%s je pracovní den: %s",
+			"%s is not a working day: %s":                                         "%s není pracovní den: %s",
+			"public holiday %s":                                                   "státní svátek %s",
+			"shops closed":                                                        "obchody zavřené",
+			"public":                                                              "státní",
+			"bank":                                                                "bankovní",
+			"observance":                                                          "významný den",
+			"optional":                                                            "volitelný",
+			"regional":                                                            "regionální",
+			"unknown region of %s: %s (use %s)":                                   "neznámý region země %s: %s (použijte %s)",
+			"invalid holiday type: %s (use %s or all)":                            "neplatný druh svátku: %s (použijte %s nebo all)",
+			"Vacation plan %d (%s): %s":                                           "Plán dovolené %d (%s): %s",
+			"No breaks planned.":                                                  "Žádné volno naplánováno.",
+			"%s for %s":                                                           "%s za %s",
+			"Month":                                                               "Měsíc",
+			"Total":                                                               "Celkem",
+			"Vacation dates: %s":                                                  "Dny dovolené: %s",
+
+			"only statutory holidays are counted for %s in %s: no schedule of days off is known": "pro %s v roce %s se počítají jen zákonné svátky: rozpis volných dnů není znám",
+
+			"Billable days report: %s": "Přehled fakturovatelných dní: %s",
+			"Summary":                  "Souhrn",
+			"Working days":             "Pracovní dny",
+			"Public holidays":          "Státní svátky",
+			"Vacation days":            "Dovolená",
+			"Billable days":            "Fakturovatelné dny",
+			"Daily rate":               "Denní sazba",
+			"Amount":                   "Částka",
+			"Legend":                   "Legenda",
+			"weekend":                  "víkend",
+			"public holiday":           "svátek",
+			"vacation":                 "dovolená",
+			"No public holidays.":      "Žádné státní svátky.",
+			"Mo":                       "Po",
+			"Tu":                       "Út",
+			"We":                       "St",
+			"Th":                       "Čt",
+			"Fr":                       "Pá",
+			"Sa":                       "So",
+			"Su":                       "Ne",
+
+			"invalid format: %v": "neplatný formát: %v",
+		},
+		Plural: map[string][]string{
+			"output.verbose": {
+				"%s: %d fakturovatelný den 💸",
+				"%s: %d fakturovatelné dny 💸",
+				"%s: %d fakturovatelných dní 💸",
+			},
+			"output.kaching": {
+				"%d den = CHA-CHING! 🤑",
+				"%d dny = CHA-CHING! 🤑",
+				"%d dní = CHA-CHING! 🤑",
+			},
+			"progress.elapsed": {
+				"uplynul %d z %d fakturovatelných dní",
+				"uplynuly %d z %d fakturovatelných dní",
+				"uplynulo %d z %d fakturovatelných dní",
+			},
+			"progress.remaining": {"zbývá %d", "zbývají %d", "zbývá %d"},
+			"due.business":       {"%d pracovní den", "%d pracovní dny", "%d pracovních dní"},
+			"due.calendar":       {"%d kalendářní den", "%d kalendářní dny", "%d kalendářních dní"},
+			"holidays.workdays": {
+				"%d z %d připadá na pracovní den",
+				"%d z %d připadají na pracovní dny",
+				"%d z %d připadá na pracovní dny",
+			},
+			"plan.off":      {"%d den volna", "%d dny volna", "%d dní volna"},
+			"plan.vacation": {"%d den dovolené", "%d dny dovolené", "%d dní dovolené"},
+			"plan.unused": {
+				"%d den dovolené zbývá: nevejde se do žádného volna",
+				"%d dny dovolené zbývají: nevejdou se do žádného volna",
+				"%d dní dovolené zbývá: nevejdou se do žádného volna",
+			},
+			"nth.fewer": {
+				"V %[2]s není ani %[3]d pracovní den",
+				"V %[2]s je méně než %[3]d pracovní dny",
+				"V %[2]s je méně než %[3]d pracovních dní",
+			},
+		},
+	},
+}
+
+const czechMainHelp = `💸 BILLME - Kalkulačka fakturovatelných dní! 💸
+
+Použití: billme [měsíc] [rok] [volby]
+         billme due [volby]
+         billme holidays [rok] [volby]
+         billme ics [období] [volby]
+         billme is-workday [datum] [volby]
+         billme number <akce> [volby]
+         billme plan-vacation [rok] [volby]
+         billme shift <datum> <±dny> | nth <n> [měsíc] [rok] | last-workday [měsíc] [rok]
+
+Přestaňte počítat na prstech - fakturujte pořádně!
+
+Příklady:
+  billme                    # Aktuální měsíc
+  billme 7                  # Červenec letošního roku
+  billme 7 2024             # Červenec 2024
+  billme čec, billme july   # Názvy měsíců česky nebo anglicky
+  billme 2024-07            # Rok a měsíc podle ISO
+  billme minulý, billme -1  # Předchozí měsíc (příští, +1 pro další)
+  billme 2024-07..2024-09   # Součet za rozsah měsíců
+  billme -v 7 2024          # Podrobný výstup
+  billme -x -d 5 7          # Bez svátků, 5 dní dovolené
+  billme -v -x --remaining  # Průběh aktuálního měsíce
+  billme --rate 6000 --iban CZ6508000000192000145399 --qr
+                            # QR platba za fakturu tohoto měsíce
+
+Volby:
+  -v, --verbose             Podrobný výstup
+  -h, --help                Zobrazit tuto nápovědu
+  -x, --exclude-holidays    Nepočítat státní svátky jako pracovní dny
+  --holiday-types <types>   Druhy svátků, které se nepočítají, zahrnuje -x
+                            (výchozí public,regional; dále bank, observance,
+                            optional nebo all)
+  --country <code>          Země svátků (výchozí CZ)
+                            nebo více spojených +, např. CZ+DE-BY
+  --region <code>           Region s vlastními svátky, např. BY
+  --intersect               Nepočítat jen dny, které jsou svátkem
+                            ve všech zemích --country, ne v kterékoli
+  -d, --vacation-days <num> Počet dní dovolené k odečtení
+  --vacation <dates>        Dny dovolené, např. 2024-07-08..2024-07-12,2024-07-22
+                            (výchozí: seznam vacation z konfiguračního souboru)
+  --remaining               Fakturovatelné dny od dneška do konce měsíce
+  --elapsed                 Fakturovatelné dny, které už tento měsíc uplynuly
+  --ka-ching                Oslavný výstup
+  --invoice-ready           Jen číslo (pro další zpracování)
+  --format <template|name>  Šablona výstupu v syntaxi Go, nebo pojmenovaná z konfigurace
+  --output <markdown|html>  Vypsat přehled s kalendářem a seznamem svátků
+  --today <YYYY-MM-DD>      Výpočet k tomuto datu (nebo nastavte BILLME_TODAY)
+  --lang <en|cs>            Jazyk výstupu (výchozí podle LANG)
+
+Platba:
+  --rate <amount>           Denní sazba, částka faktury = dny × sazba
+  --currency <code>         Měna faktury (výchozí CZK)
+  --iban <iban>             Účet příjemce
+  --vs <symbol>             Variabilní symbol (až 10 číslic)
+  --message <text>          Zpráva pro příjemce
+  --due <YYYY-MM-DD>        Datum splatnosti
+  --qr                      Vypsat QR platbu do terminálu
+  --qr-png <file>           Uložit QR platbu do souboru PNG
+  --crc32                   Přidat do QR kódu kontrolní součet CRC32
+
+Faktura:
+  --isdoc <file>            Uložit elektronickou fakturu ISDOC
+  --invoice-number <id>     Číslo faktury (výchozí: další v řadě)
+  --client <name>           Klient z konfiguračního souboru
+  --vat <percent>           Sazba DPH (0 pro neplátce)
+  --exchange-rate <czk>     Kurz v CZK za jednotku cizí měny --currency
+  --config <file>           Konfigurační soubor se sazbou, IBAN a údaji o stranách
+`
+
+const czechUsageHint = `Použití: billme [měsíc] [rok] [volby]
+Více informací zobrazí -help
+`
+
+const czechDueHelp = `Použití: billme due [volby]
+
+Spočítá datum splatnosti faktury. Splatnost v kalendářních dnech se posune
+na další pracovní den, pokud připadne na víkend nebo svátek.
+
+Příklady:
+  billme due --issued 2024-07-31 --net 14             # 14 kalendářních dní
+  billme due --issued 2024-07-31 --net 10 --business  # 10 pracovních dní
+
+Volby:
+  --issued <YYYY-MM-DD>     Datum vystavení (výchozí dnes)
+  --net <days>              Splatnost ve dnech (výchozí 14)
+  --business                Počítat pracovní dny místo kalendářních
+  --country <code>          Země, jejíž svátky se přeskakují (výchozí CZ)
+                            nebo více spojených +, např. CZ+DE-BY
+  --region <code>           Region země s vlastními svátky
+  --today <YYYY-MM-DD>      Datum, které se považuje za dnešek (nebo BILLME_TODAY)
+  --lang <en|cs>            Jazyk výstupu (výchozí podle LANG)
+  -v, --verbose             Vysvětlit výpočet
+`
+
+const czechNumberHelp = `Použití: billme number <akce> [číslo] [volby]
+
+Vydává a spravuje průběžná čísla faktur.
+
+Akce:
+  next                      Vydat další číslo řady
+  void <number>             Zneplatnit vydané číslo (zůstává použité)
+  reissue <number>          Znovu použít zneplatněné číslo
+  list                      Vypsat vydaná čísla a auditní záznam
+
+Volby:
+  --client <name>           Klient z konfiguračního souboru (vlastní prefix/vzor)
+  --reason <text>           Důvod zneplatnění či obnovení (povinný)
+  --date <YYYY-MM-DD>       Datum vystavení, které určuje řadu (výchozí dnes)
+  --today <YYYY-MM-DD>      Datum, které se považuje za dnešek (nebo BILLME_TODAY)
+  --config <file>           Konfigurační soubor
+  --lang <en|cs>            Jazyk výstupu (výchozí podle LANG)
+`
+
+const czechWorkdaysHelp = `Použití: billme shift <YYYY-MM-DD> <±dny> [volby]
+         billme nth <n> [měsíc] [rok] [volby]
+         billme last-workday [měsíc] [rok] [volby]
+
+Počítání s pracovními dny, které přeskakuje víkendy a svátky.
+
+Příklady:
+  billme shift 2024-07-01 10      # 10 pracovních dní po 1. červenci
+  billme shift 2024-07-08 -1      # pracovní den před 8. červencem
+  billme nth 1 1 2024             # první pracovní den ledna 2024
+  billme nth -2 12                # předposlední pracovní den prosince
+  billme last-workday             # den fakturace v tomto měsíci
+
+Volby:
+  --country <code>          Země, jejíž svátky se přeskakují (výchozí CZ)
+                            nebo více spojených +, např. CZ+DE-BY
+  --region <code>           Region s vlastními svátky
+  --intersect               Přeskakovat jen svátky ve všech zemích
+  --holiday-types <types>   Druhy svátků, které se přeskakují (výchozí public,regional)
+  --ignore-holidays         Považovat svátky za pracovní dny
+  --work-week <days>        Pracovní dny v týdnu (výchozí mon-fri)
+  --today <YYYY-MM-DD>      Datum, které se považuje za dnešek (nebo BILLME_TODAY)
+  --lang <en|cs>            Jazyk výstupu (výchozí podle LANG)
+`
+
+const czechHolidaysHelp = `Použití: billme holidays [rok] [volby]
+
+Vypíše státní svátky roku s místním i anglickým názvem, napřed tím v
+jazyce výstupu, s druhem svátku, tím, zda mají obchody zavřeno, a zda
+svátek připadá na pracovní den. JSON a CSV přidávají identifikátor a
+zákon, který svátek stanoví.
+
+Příklady:
+  billme holidays                 # svátky letošního roku
+  billme holidays 2025 --output csv
+  billme holidays --output ics > svatky.ics
+  billme holidays --country DE --region BY
+
+Volby:
+  --country <code>          Země svátků (výchozí CZ)
+                            nebo více spojených +, např. CZ+DE-BY
+  --region <code>           Region s vlastními svátky
+  --intersect               Vypsat jen svátky společné všem zemím
+  --output <json|csv|ics>   Strojově čitelný výstup místo tabulky
+  --work-week <days>        Pracovní dny v týdnu (výchozí mon-fri)
+  --today <YYYY-MM-DD>      Datum, které se považuje za dnešek (nebo BILLME_TODAY)
+  --lang <en|cs>            Jazyk výstupu (výchozí podle LANG)
+`
+
+const czechICSHelp = `Použití: billme ics [období] [volby]
+
+Exportuje státní svátky a vaši dovolenou jako celodenní události
+kalendáře (iCalendar, RFC 5545) k importu do libovolné kalendářové
+aplikace. Události si mezi exporty zachovávají stejné UID, takže opětovný
+import je aktualizuje místo přidání duplicit.
+
+Obdobím je rok, měsíc nebo rozsah měsíců jako při výpočtu
+fakturovatelných dní; výchozí je letošní rok. Dovolená se bere z
+--vacation nebo ze seznamu "vacation" v konfiguračním souboru.
+
+Příklady:
+  billme ics > kalendar.ics        # svátky a dovolená letošního roku
+  billme ics 2025 --no-vacation    # jen svátky roku 2025
+  billme ics 7..9 --vacation 2024-07-08..2024-07-12
+
+Volby:
+  --country <code>          Země svátků (výchozí CZ)
+                            nebo více spojených +, např. CZ+DE-BY
+  --region <code>           Region s vlastními svátky
+  --intersect               Exportovat jen svátky společné všem zemím
+  --vacation <dates>        Dny dovolené místo konfiguračního souboru
+  --no-holidays             Exportovat jen dovolenou
+  --no-vacation             Exportovat jen svátky
+  --work-week <days>        Pracovní dny v týdnu (výchozí mon-fri)
+  --config <file>           Konfigurační soubor se zaznamenanou dovolenou
+  --today <YYYY-MM-DD>      Datum, které se považuje za dnešek (nebo BILLME_TODAY)
+  --lang <en|cs>            Jazyk výstupu (výchozí podle LANG)
+`
+
+const czechIsWorkdayHelp = `Použití: billme is-workday [RRRR-MM-DD] [volby]
+
+Zjistí, zda je datum, výchozí dnešek, pracovní den. Pro pracovní den
+skončí s kódem 0, pro víkend, státní svátek nebo dovolenou s kódem 1,
+takže na něm mohou záviset skripty a úlohy cronu; chyby končí kódem 2
+nebo vyšším.
+
+Příklady:
+  billme is-workday && ./odeslat-faktury.sh
+  billme is-workday 2024-07-05 -v   # vypíše důvod
+  0 9 * * * billme is-workday && ./denni-prehled.sh
+
+Volby:
+  -v, --verbose             Vypsat, proč den je nebo není pracovní
+  --country <code>          Země, jejíž svátky jsou volno (výchozí CZ)
+                            nebo více spojených +, např. CZ+DE-BY
+  --region <code>           Region s vlastními svátky
+  --intersect               Volno jsou jen svátky ve všech zemích
+  --holiday-types <types>   Druhy svátků, které jsou volno
+                            (výchozí public,regional; all pro všechny druhy)
+  --ignore-holidays         Považovat svátky za pracovní dny
+  --vacation <dates>        Dny dovolené místo konfiguračního souboru
+  --work-week <days>        Pracovní dny v týdnu (výchozí mon-fri)
+  --config <file>           Konfigurační soubor se zaznamenanou dovolenou
+  --today <YYYY-MM-DD>      Datum, které se považuje za dnešek (nebo BILLME_TODAY)
+  --lang <en|cs>            Jazyk výstupu (výchozí podle LANG)
+`
+
+const czechPlanHelp = `Použití: billme plan-vacation [rok] [volby]
+
+Navrhne dny dovolené v roce, výchozí letošním, které dají co nejvíc
+volných dní v kuse: nejdřív mosty mezi víkendy a svátky, pak celé týdny
+rozložené přes rok a ze zbylých dní prodloužené víkendy. Fakturovatelné
+dny každého měsíce s vybranou dovolenou, a jejich částka, je-li známa
+sazba, ukážou, co volno stojí. Dny se vypíšou v zápisu --vacation,
+připravené pro seznam "vacation" v konfiguračním souboru.
+
+Příklady:
+  billme plan-vacation 2025 --days 25
+  billme plan-vacation --country DE --region BY --rate 6000 --currency EUR
+
+Volby:
+  --days <n>                Počet dní dovolené k naplánování (výchozí 20)
+  --rate <amount>           Denní sazba pro částku každého měsíce
+                            (výchozí z konfiguračního souboru)
+  --currency <code>         Měna sazby (výchozí CZK)
+  --country <code>          Země, jejíž svátky jsou volno (výchozí CZ)
+                            nebo více spojených +, např. CZ+DE-BY
+  --region <code>           Region s vlastními svátky
+  --intersect               Volno jsou jen svátky ve všech zemích
+  --holiday-types <types>   Druhy svátků, které jsou volno
+                            (výchozí public,regional; all pro všechny druhy)
+  --ignore-holidays         Považovat svátky za pracovní dny
+  --work-week <days>        Pracovní dny v týdnu (výchozí mon-fri)
+  --config <file>           Konfigurační soubor se sazbou a měnou
+  --today <YYYY-MM-DD>      Datum, které se považuje za dnešek (nebo BILLME_TODAY)
+  --lang <en|cs>            Jazyk výstupu (výchozí podle LANG)
+`
@@ -3,19 +3,61 @@ package cli
 import (
 	"flag"
 	"fmt"
-	"strconv"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/clock"
+	"github.com/honzahovorka/billme/internal/holidays"
+	"github.com/honzahovorka/billme/internal/i18n"
+	"github.com/honzahovorka/billme/internal/isdoc"
+	"github.com/honzahovorka/billme/internal/settings"
+	"github.com/honzahovorka/billme/internal/spayd"
+	"github.com/honzahovorka/billme/pkg/workdays"
+	"os"
+	"strings"
+	"text/template"
 	"time"
 )
 
+// systemClock is the clock used when neither --today nor BILLME_TODAY is
+// given; tests replace it to get a deterministic date.
+var systemClock clock.Clock = clock.System{}
+
 type Config struct {
 	Month           int
 	Year            int
+	EndMonth        int // last month of a range; equal to Month otherwise
+	EndYear         int
 	Verbose         bool
 	KaChing         bool
 	InvoiceReady    bool
 	Help            bool
 	ExcludeHolidays bool
+	HolidayTypes    holidays.HolidayTypes // excluded with ExcludeHolidays; zero means the default types
+	Country         string
+	Region          string
+	Intersect       bool // holidays of all the countries combined in Country, not any
 	VacationDays    int
+	Vacation        []time.Time // vacation dates given with --vacation
+	Remaining       bool
+	Elapsed         bool
+	Today           time.Time
+	Clock           clock.Clock
+	Rate            float64
+	Currency        string
+	ExchangeRate    float64 // CZK for one unit of Currency on an ISDOC invoice
+	IBAN            string
+	VariableSymbol  string
+	Message         string
+	DueDate         time.Time
+	QR              bool
+	QRPNG           string
+	CRC32           bool
+	ISDOC           string
+	InvoiceNumber   string
+	Client          string
+	VATRate         float64
+	Format          *template.Template
+	Output          string
+	Settings        settings.Settings
 }
 
 func ParseArgs() (*Config, error) {
@@ -30,100 +72,585 @@ func ParseArgs() (*Config, error) {
 	// Short flags
 	flag.BoolVar(&verboseFlag, "v", false, "verbose output")
 	flag.BoolVar(&helpFlag, "h", false, "show help")
-	flag.BoolVar(&excludeHolidaysFlag, "x", false, "exclude Czech public holidays")
+	flag.BoolVar(&excludeHolidaysFlag, "x", false, "exclude public holidays")
 	flag.IntVar(&vacationDaysFlag, "d", 0, "vacation/time-off days to subtract")
 
 	// Long flags (same variables)
 	flag.BoolVar(&verboseFlag, "verbose", false, "verbose output")
 	flag.BoolVar(&helpFlag, "help", false, "show help")
-	flag.BoolVar(&excludeHolidaysFlag, "exclude-holidays", false, "exclude Czech public holidays from working days")
+	flag.BoolVar(&excludeHolidaysFlag, "exclude-holidays", false, "exclude public holidays from working days")
 	flag.IntVar(&vacationDaysFlag, "vacation-days", 0, "number of vacation/time-off days to subtract")
 
 	// Flags that only have long forms
 	kaching := flag.Bool("ka-ching", false, "celebratory output")
 	invoiceReady := flag.Bool("invoice-ready", false, "clean number only")
+	format := flag.String("format", "", "output template, or the name of a template from the config file")
+	output := flag.String("output", "", "print a report document: markdown or html")
+	vacation := flag.String("vacation", "", "vacation dates, e.g. 2024-07-08..2024-07-12,2024-07-22")
+	remaining := flag.Bool("remaining", false, "billable days from today to the end of the month")
+	elapsed := flag.Bool("elapsed", false, "billable days of the month before today")
+	country := flag.String("country", "CZ", "country whose holidays are excluded")
+	region := flag.String("region", "", "region of the country with its own holidays")
+	intersect := flag.Bool("intersect", false, "exclude only the days that are holidays in all the countries")
+	holidayTypes := flag.String("holiday-types", "", "types of holiday to exclude, e.g. public,bank (implies -x)")
+
+	// Invoice amount and QR Platba payment
+	rate := flag.Float64("rate", 0, "daily rate used to compute the invoice amount")
+	currency := flag.String("currency", "CZK", "invoice currency (ISO 4217)")
+	exchangeRate := flag.Float64("exchange-rate", 0, "CZK for one unit of a foreign invoice currency")
+	iban := flag.String("iban", "", "IBAN of the account to be paid")
+	variableSymbol := flag.String("vs", "", "variable symbol for the payment")
+	message := flag.String("message", "", "message for the recipient")
+	dueDate := flag.String("due", "", "payment due date (YYYY-MM-DD)")
+	qr := flag.Bool("qr", false, "print QR Platba payment code to the terminal")
+	qrPNG := flag.String("qr-png", "", "write QR Platba payment code to a PNG file")
+	crc := flag.Bool("crc32", false, "include CRC32 checksum in the payment code")
+
+	// ISDOC electronic invoice
+	isdocFile := flag.String("isdoc", "", "write an ISDOC invoice to a file")
+	invoiceNumber := flag.String("invoice-number", "", "invoice number for the ISDOC invoice (default: next in series)")
+	client := flag.String("client", "", "client from the config file")
+	vatRate := flag.Float64("vat", 0, "VAT rate in percent (0 if not a VAT payer)")
 
-	flag.Parse()
+	configPath := flag.String("config", settings.DefaultPath(), "path to the config file")
+	today := flag.String("today", "", "pretend today is this date (YYYY-MM-DD)")
+	lang := flag.String("lang", "", "output language: en or cs (default from LANG)")
+
+	detectLanguage()
+	positional, err := parseInterspersed(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+	if err := setLanguage(*lang); err != nil {
+		return nil, err
+	}
 
 	config.Verbose = verboseFlag
 	config.KaChing = *kaching
 	config.InvoiceReady = *invoiceReady
 	config.Help = helpFlag
 	config.ExcludeHolidays = excludeHolidaysFlag
+	config.Country = *country
+	config.Region = *region
+	config.Intersect = *intersect
 	config.VacationDays = vacationDaysFlag
+	config.Remaining = *remaining
+	config.Output = *output
+	config.Elapsed = *elapsed
+	config.Rate = *rate
+	config.Currency = *currency
+	config.ExchangeRate = *exchangeRate
+	config.IBAN = *iban
+	config.VariableSymbol = *variableSymbol
+	config.Message = *message
+	config.QR = *qr
+	config.QRPNG = *qrPNG
+	config.CRC32 = *crc
+	config.ISDOC = *isdocFile
+	config.InvoiceNumber = *invoiceNumber
+	config.Client = *client
+	config.VATRate = *vatRate
 
 	if config.Help {
 		return config, nil
 	}
 
-	args := flag.Args()
-	now := time.Now()
+	explicit := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	fileSettings, err := settings.Load(*configPath, explicit["config"])
+	if err != nil {
+		return nil, err
+	}
+	applySettings(config, fileSettings, explicit)
 
-	if len(args) == 0 {
-		config.Month = int(now.Month())
-		config.Year = now.Year()
-	} else if len(args) == 1 {
-		month, err := strconv.Atoi(args[0])
-		if err != nil || month < 1 || month > 12 {
-			return nil, fmt.Errorf("invalid month: %s", args[0])
+	if _, err := config.Settings.CustomerParty(config.Client); err != nil {
+		return nil, err
+	}
+
+	if *format != "" {
+		config.Format, err = parseFormat(*format, config.Settings.Templates)
+		if err != nil {
+			return nil, err
 		}
-		config.Month = month
-		config.Year = now.Year()
-	} else if len(args) == 2 {
-		month, err := strconv.Atoi(args[0])
-		if err != nil || month < 1 || month > 12 {
-			return nil, fmt.Errorf("invalid month: %s", args[0])
+	}
+
+	if *dueDate != "" {
+		due, err := time.Parse("2006-01-02", *dueDate)
+		if err != nil {
+			return nil, tr.Errorf("invalid due date: %s", *dueDate)
 		}
-		year, err := strconv.Atoi(args[1])
+		config.DueDate = due
+	}
+
+	if config.Rate < 0 {
+		return nil, tr.Errorf("invalid rate: %v", config.Rate)
+	}
+
+	if config.QR || config.QRPNG != "" {
+		if config.IBAN == "" {
+			return nil, tr.Errorf("--iban is required for a QR payment code")
+		}
+		if config.Rate == 0 {
+			return nil, tr.Errorf("--rate is required for a QR payment code")
+		}
+	}
+
+	if config.ISDOC != "" && config.Rate == 0 {
+		return nil, tr.Errorf("--rate is required for an ISDOC invoice")
+	}
+	if config.ExchangeRate < 0 {
+		return nil, tr.Errorf("invalid exchange rate: %v", config.ExchangeRate)
+	}
+	if config.ISDOC != "" && config.ExchangeRate == 0 && !strings.EqualFold(config.Currency, "CZK") {
+		return nil, tr.Errorf("--exchange-rate is required for an ISDOC invoice in %s", strings.ToUpper(config.Currency))
+	}
+
+	if *holidayTypes != "" {
+		config.HolidayTypes, err = holidays.ParseHolidayTypes(*holidayTypes)
 		if err != nil {
-			return nil, fmt.Errorf("invalid year: %s", args[1])
+			return nil, Localize(err)
+		}
+		config.ExcludeHolidays = true
+	}
+
+	if config.Remaining && config.Elapsed {
+		return nil, tr.Errorf("--remaining and --elapsed cannot be combined")
+	}
+
+	config.Clock, err = resolveClock(*today)
+	if err != nil {
+		return nil, err
+	}
+	config.Today = clock.Today(config.Clock)
+
+	start, end, err := parsePeriod(positional, config.Today)
+	if err != nil {
+		return nil, err
+	}
+	config.Month, config.Year = start.month, start.year
+	config.EndMonth, config.EndYear = end.month, end.year
+
+	if config.IsRange() && (config.Remaining || config.Elapsed) {
+		return nil, tr.Errorf("--remaining and --elapsed need a single month")
+	}
+
+	switch config.Output {
+	case "", OutputMarkdown, OutputHTML:
+	default:
+		return nil, tr.Errorf("unknown output format: %s (use markdown or html)", config.Output)
+	}
+	if config.Output != "" && (config.Format != nil || config.Remaining || config.Elapsed) {
+		return nil, tr.Errorf("--output cannot be combined with --format, --remaining or --elapsed")
+	}
+
+	first := time.Date(config.Year, time.Month(config.Month), 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(config.EndYear, time.Month(config.EndMonth)+1, 0, 0, 0, 0, 0, time.UTC)
+	if *vacation != "" {
+		config.Vacation, err = parseDates(*vacation)
+		if err != nil {
+			return nil, err
+		}
+		for _, date := range config.Vacation {
+			if date.Before(first) || date.After(last) {
+				return nil, tr.Errorf("vacation date %s is outside the billed period", date.Format("2006-01-02"))
+			}
 		}
-		config.Month = month
-		config.Year = year
 	} else {
-		return nil, fmt.Errorf("too many arguments")
+		// The vacation recorded in the config file spans any number of
+		// months; only the billed ones count.
+		recorded, err := recordedVacation(config.Settings)
+		if err != nil {
+			return nil, err
+		}
+		for _, date := range recorded {
+			if !date.Before(first) && !date.After(last) {
+				config.Vacation = append(config.Vacation, date)
+			}
+		}
 	}
 
 	return config, nil
 }
 
+// IsRange reports whether the report covers more than one month.
+func (c *Config) IsRange() bool {
+	start, end := c.period()
+	return start != end
+}
+
+// period returns the first and last billed month. A config without an end
+// month covers just the start month.
+func (c *Config) period() (monthYear, monthYear) {
+	start := monthYear{c.Month, c.Year}
+	if c.EndMonth == 0 {
+		return start, start
+	}
+	return start, monthYear{c.EndMonth, c.EndYear}
+}
+
+// Calculation returns the billed period and the options for
+// workdays.Calculate. The holidays are Czech unless Country says otherwise;
+// Today only splits the days of a single month.
+func (c *Config) Calculation() (workdays.Period, workdays.Options) {
+	start, end := c.period()
+	country := c.Country
+	if country == "" {
+		country = "CZ"
+	}
+	opts := workdays.Options{
+		Country:         country,
+		Region:          c.Region,
+		Intersect:       c.Intersect,
+		ExcludeHolidays: c.ExcludeHolidays,
+		HolidayTypes:    c.HolidayTypes,
+		VacationDays:    c.VacationDays,
+	}
+	if !c.IsRange() {
+		opts.Today = c.Today
+	}
+	for _, date := range c.Vacation {
+		opts.Leave = append(opts.Leave, workdays.Leave{Date: date})
+	}
+	return workdays.Months(start.year, time.Month(start.month), end.year, time.Month(end.month)), opts
+}
+
+// ScheduleWarning returns a warning when holidays are excluded in years
+// whose schedule of days off, such as China's, billme does not know, so
+// only the statutory holidays are counted; otherwise it returns "".
+func ScheduleWarning(period workdays.Period, opts workdays.Options) string {
+	if !opts.ExcludeHolidays || opts.Provider != nil {
+		return ""
+	}
+	provider, err := calculator.ResolveProvider(opts.Country, opts.Region, opts.Intersect, opts.HolidayTypes)
+	if err != nil {
+		return ""
+	}
+	var years []string
+	for year := period.Start.Year(); year <= period.End.Year(); year++ {
+		if !holidays.HasSchedule(provider, year) {
+			years = append(years, fmt.Sprint(year))
+		}
+	}
+	if years == nil {
+		return ""
+	}
+	return tr.Sprintf("only statutory holidays are counted for %s in %s: no schedule of days off is known", strings.ToUpper(opts.Country), strings.Join(years, ", "))
+}
+
+// parseDates parses a comma-separated list of dates and inclusive ranges,
+// e.g. "2024-07-08..2024-07-12,2024-07-22".
+func parseDates(value string) ([]time.Time, error) {
+	var dates []time.Time
+	for _, part := range strings.Split(value, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "..")
+		start, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return nil, tr.Errorf("invalid date: %s", from)
+		}
+		end := start
+		if isRange {
+			if end, err = time.Parse("2006-01-02", to); err != nil {
+				return nil, tr.Errorf("invalid date: %s", to)
+			}
+			if end.Before(start) {
+				return nil, tr.Errorf("range ends before it starts: %s", part)
+			}
+		}
+		for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+			dates = append(dates, date)
+		}
+	}
+	return dates, nil
+}
+
+// recordedVacation returns the dates of the vacation list in the config
+// file, which uses the syntax of --vacation.
+func recordedVacation(s settings.Settings) ([]time.Time, error) {
+	if len(s.Vacation) == 0 {
+		return nil, nil
+	}
+	return parseDates(strings.Join(s.Vacation, ","))
+}
+
+// loadVacation returns the vacation dates given with --vacation, or else the
+// ones recorded in the config file at path.
+func loadVacation(flagValue, path string, required bool) ([]time.Time, error) {
+	if flagValue != "" {
+		return parseDates(flagValue)
+	}
+	fileSettings, err := settings.Load(path, required)
+	if err != nil {
+		return nil, err
+	}
+	return recordedVacation(*fileSettings)
+}
+
+// resolveClock returns the clock for a command given its --today value.
+func resolveClock(today string) (clock.Clock, error) {
+	c, err := clock.Resolve(today, systemClock)
+	if err != nil {
+		return nil, Localize(err)
+	}
+	return c, nil
+}
+
+// applySettings fills in values from the config file for every flag the
+// user did not pass explicitly.
+func applySettings(config *Config, s *settings.Settings, explicit map[string]bool) {
+	config.Settings = *s
+
+	if !explicit["rate"] && s.Rate != 0 {
+		config.Rate = s.Rate
+	}
+	if !explicit["currency"] && s.Currency != "" {
+		config.Currency = s.Currency
+	}
+	if !explicit["iban"] && s.IBAN != "" {
+		config.IBAN = s.IBAN
+	}
+	if !explicit["vat"] && s.VATRate != 0 {
+		config.VATRate = s.VATRate
+	}
+}
+
+const mainHelp = `💸 BILLME - Your billable days calculator! 💸
+
+Usage: billme [month] [year] [options]
+       billme due [options]
+       billme holidays [year] [options]
+       billme ics [period] [options]
+       billme is-workday [date] [options]
+       billme number <action> [options]
+       billme plan-vacation [year] [options]
+       billme shift <date> <±days> | nth <n> [month] [year] | last-workday [month] [year]
+
+Stop counting on your fingers - let me bill you properly!
+
+Examples:
+  billme                    # Current month
+  billme 7                  # July this year
+  billme 7 2024             # July 2024
+  billme july, billme čec   # Month names in English or Czech
+  billme 2024-07            # ISO year and month
+  billme last, billme -1    # Previous month (next, +1 for the next one)
+  billme 2024-07..2024-09   # Sum over a range of months
+  billme -v 7 2024          # Verbose output
+  billme -x -d 5 7          # Exclude holidays, 5 vacation days
+  billme -v -x --remaining  # Progress through the current month
+  billme --rate 6000 --iban CZ6508000000192000145399 --qr
+                            # QR Platba for this month's invoice
+
+Options:
+  -v, --verbose             Verbose output
+  -h, --help                Show this help
+  -x, --exclude-holidays    Exclude public holidays from working days
+  --holiday-types <types>   Types of holiday to exclude, implies -x
+                            (default public,regional; also bank, observance,
+                            optional or all)
+  --country <code>          Country of the holidays (default CZ)
+                            or several joined by +, e.g. CZ+DE-BY
+  --region <code>           Region with its own holidays, e.g. BY
+  --intersect               Exclude only the days that are holidays in all
+                            the countries of --country, not in any
+  -d, --vacation-days <num> Number of vacation/time-off days to subtract
+  --vacation <dates>        Vacation dates, e.g. 2024-07-08..2024-07-12,2024-07-22
+                            (default: the vacation list of the config file)
+  --remaining               Billable days left from today to month end
+  --elapsed                 Billable days already behind you this month
+  --ka-ching                Celebratory output
+  --invoice-ready           Clean number only (for piping)
+  --format <template|name>  Go template for the output, or a named one from the config
+  --output <markdown|html>  Print a report with a calendar and holiday list
+  --today <YYYY-MM-DD>      Report as of this date (or set BILLME_TODAY)
+  --lang <en|cs>            Output language (default from LANG)
+
+Payment:
+  --rate <amount>           Daily rate, invoice amount = days × rate
+  --currency <code>         Invoice currency (default CZK)
+  --iban <iban>             Account to be paid
+  --vs <symbol>             Variable symbol (up to 10 digits)
+  --message <text>          Message for the recipient
+  --due <YYYY-MM-DD>        Payment due date
+  --qr                      Print QR Platba code to the terminal
+  --qr-png <file>           Write QR Platba code to a PNG file
+  --crc32                   Include CRC32 checksum in the QR code
+
+Invoice:
+  --isdoc <file>            Write an ISDOC electronic invoice
+  --invoice-number <id>     Invoice number (default: next in series)
+  --client <name>           Client from the config file
+  --vat <percent>           VAT rate (0 if not a VAT payer)
+  --exchange-rate <czk>     CZK for one unit of a foreign --currency
+  --config <file>           Config file with rate, IBAN and party details
+`
+
 func ShowHelp() {
-	fmt.Println("💸 BILLME - Your billable days calculator! 💸")
-	fmt.Println()
-	fmt.Println("Usage: billme [month] [year] [options]")
-	fmt.Println()
-	fmt.Println("Stop counting on your fingers - let me bill you properly!")
-	fmt.Println()
-	fmt.Println("Examples:")
-	fmt.Println("  billme                    # Current month")
-	fmt.Println("  billme 7                  # July this year")
-	fmt.Println("  billme 7 2024             # July 2024")
-	fmt.Println("  billme -v 7 2024          # Verbose output")
-	fmt.Println("  billme -x -d 5 7          # Exclude holidays, 5 vacation days")
-	fmt.Println()
-	fmt.Println("Options:")
-	fmt.Println("  -v, --verbose             Verbose output")
-	fmt.Println("  -h, --help                Show this help")
-	fmt.Println("  -x, --exclude-holidays    Exclude Czech public holidays from working days")
-	fmt.Println("  -d, --vacation-days <num> Number of vacation/time-off days to subtract")
-	fmt.Println("  --ka-ching                Celebratory output")
-	fmt.Println("  --invoice-ready           Clean number only (for piping)")
+	fmt.Print(tr.Text(mainHelp))
 }
 
+const usageHint = `Usage: billme [month] [year] [options]
+Use -help for more information
+`
+
 func ShowUsage() {
-	fmt.Println("Usage: billme [month] [year] [options]")
-	fmt.Println("Use -help for more information")
+	fmt.Print(tr.Text(usageHint))
+}
+
+// PaymentAmount returns the invoice amount for the given billable days.
+func PaymentAmount(workingDays int, config *Config) float64 {
+	return float64(workingDays) * config.Rate
+}
+
+// Payment builds the QR Platba payment for the invoice amount.
+func Payment(workingDays int, config *Config) spayd.Payment {
+	return spayd.Payment{
+		IBAN:           config.IBAN,
+		BIC:            config.Settings.BIC,
+		Amount:         PaymentAmount(workingDays, config),
+		Currency:       config.Currency,
+		VariableSymbol: config.VariableSymbol,
+		Message:        config.Message,
+		DueDate:        config.DueDate,
+	}
+}
+
+// Invoice builds the ISDOC invoice for the billed months, issued on the given
+// date. The tax point is the last day of the last month and, unless --due is set,
+// payment is due after the configured payment terms (14 days by default),
+// rolled forward to the next working day of the country and region, as
+// billme due does.
+func Invoice(workingDays int, config *Config, issued time.Time) (isdoc.Invoice, error) {
+	s := config.Settings
+	customer, _ := s.CustomerParty(config.Client)
+
+	dueDate := config.DueDate
+	if dueDate.IsZero() {
+		terms := s.PaymentTermsDays
+		if terms == 0 {
+			terms = 14
+		}
+		_, opts := config.Calculation()
+		calendar, err := dueCalendar(opts.Country, opts.Region)
+		if err != nil {
+			return isdoc.Invoice{}, err
+		}
+		dueDate = calculator.DueDate(calendar, issued, terms, false)
+	}
+
+	description := s.ItemDescription
+	if description == "" {
+		description = "Services"
+	}
+	start, end := config.period()
+	description = fmt.Sprintf("%s %02d/%d", description, start.month, start.year)
+	if start != end {
+		description += fmt.Sprintf("–%02d/%d", end.month, end.year)
+	}
+
+	return isdoc.Invoice{
+		Number:         config.InvoiceNumber,
+		IssueDate:      issued,
+		TaxPointDate:   time.Date(end.year, time.Month(end.month)+1, 0, 0, 0, 0, 0, time.UTC),
+		DueDate:        dueDate,
+		Currency:       config.Currency,
+		ExchangeRate:   config.ExchangeRate,
+		Supplier:       isdocParty(s.Supplier),
+		Customer:       isdocParty(customer),
+		Description:    description,
+		Quantity:       float64(workingDays),
+		Unit:           "DAY",
+		UnitPrice:      config.Rate,
+		VATRate:        config.VATRate,
+		IBAN:           config.IBAN,
+		BIC:            s.BIC,
+		VariableSymbol: config.VariableSymbol,
+	}, nil
+}
+
+func isdocParty(p settings.Party) isdoc.Party {
+	return isdoc.Party{
+		ID:             p.ID,
+		VATID:          p.VATID,
+		Name:           p.Name,
+		Street:         p.Street,
+		BuildingNumber: p.BuildingNumber,
+		City:           p.City,
+		PostalCode:     p.PostalCode,
+		Country:        p.Country,
+	}
 }
 
 func FormatOutput(workingDays int, config *Config) string {
 	if config.InvoiceReady {
 		return fmt.Sprintf("%d", workingDays)
 	} else if config.KaChing {
-		return fmt.Sprintf("%d days = CHA-CHING! 🤑", workingDays)
+		return tr.Plural("output.kaching", workingDays, workingDays)
 	} else if config.Verbose {
-		monthName := time.Month(config.Month).String()
-		return fmt.Sprintf("%s %d: %d billable days 💸", monthName, config.Year, workingDays)
+		start, end := config.period()
+		name := i18n.Capitalize(periodName(start, end, i18n.Nominative))
+		return tr.Plural("output.verbose", workingDays, name, workingDays)
 	} else {
 		return fmt.Sprintf("💰 %d", workingDays)
 	}
 }
+
+// FormatExcludedHolidays lists, for the verbose output, the holidays
+// excluded from the workdays of a calendar that combines countries, each
+// with the country it is a holiday in. It is empty for other calendars and
+// output styles.
+func FormatExcludedHolidays(days []workdays.Day, config *Config) string {
+	if !config.Verbose || config.InvoiceReady || config.KaChing {
+		return ""
+	}
+	types := config.HolidayTypes
+	if types == 0 {
+		types = holidays.DefaultHolidayTypes
+	}
+
+	var lines []string
+	for _, day := range days {
+		if day.Kind != workdays.Holiday || day.Holidays[0].Country == "" {
+			continue
+		}
+		var names []string
+		for _, holiday := range day.Holidays {
+			if types.Has(holiday.Type) {
+				names = append(names, holidayName(holiday))
+			}
+		}
+		lines = append(lines, fmt.Sprintf("  %s %-9s  %s", day.Date.Format("2006-01-02"), tr.Weekday(day.Weekday), strings.Join(names, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FormatProgress renders the split of the month at today: the remaining or
+// elapsed count in the usual styles, or a progress bar when verbose.
+func FormatProgress(elapsed, remaining int, config *Config) string {
+	if !config.Verbose || config.InvoiceReady || config.KaChing {
+		if config.Elapsed {
+			return FormatOutput(elapsed, config)
+		}
+		return FormatOutput(remaining, config)
+	}
+
+	total := elapsed + remaining
+	percent := 0
+	if total > 0 {
+		percent = elapsed * 100 / total
+	}
+
+	const width = 20
+	filled := percent * width / 100
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+
+	// Languages differ in the case the month takes: "July 2024: …" but
+	// "V červenci 2024 …", so both forms are passed to the message.
+	start, end := config.period()
+	return tr.Sprintf("%[1]s: %[3]s, %[4]s [%[5]s] %[6]d%% 💸",
+		i18n.Capitalize(periodName(start, end, i18n.Nominative)),
+		periodName(start, end, i18n.Locative),
+		tr.Plural("progress.elapsed", elapsed, elapsed, total),
+		tr.Plural("progress.remaining", remaining, remaining),
+		bar, percent)
+}
@@ -1,13 +1,33 @@
 package cli
 
 import (
+	"cmp"
+	"context"
 	"flag"
 	"fmt"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/clock"
+	"github.com/honzahovorka/billme/internal/holidays"
+	"github.com/honzahovorka/billme/internal/i18n"
+	"github.com/honzahovorka/billme/pkg/workdays"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
 
+func TestMain(m *testing.M) {
+	// Keep the developer's own config file out of the tests.
+	os.Setenv("BILLME_CONFIG", filepath.Join(os.TempDir(), "billme-test-missing-config.json"))
+	os.Unsetenv(clock.EnvToday)
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		os.Unsetenv(name)
+	}
+	systemClock = clock.Fixed(time.Date(2024, 7, 15, 9, 30, 0, 0, time.UTC))
+	os.Exit(m.Run())
+}
+
 func TestFormatOutput(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -149,12 +169,11 @@ func TestParseArgsNoArguments(t *testing.T) {
 		t.Errorf("ParseArgs() should not return error for no arguments: %v", err)
 	}
 
-	now := time.Now()
-	if config.Month != int(now.Month()) {
-		t.Errorf("Expected current month %d, got %d", int(now.Month()), config.Month)
+	if config.Month != 7 {
+		t.Errorf("Expected current month 7, got %d", config.Month)
 	}
-	if config.Year != now.Year() {
-		t.Errorf("Expected current year %d, got %d", now.Year(), config.Year)
+	if config.Year != 2024 {
+		t.Errorf("Expected current year 2024, got %d", config.Year)
 	}
 }
 
@@ -173,8 +192,8 @@ func TestParseArgsOneArgument(t *testing.T) {
 	if config.Month != 7 {
 		t.Errorf("Expected month 7, got %d", config.Month)
 	}
-	if config.Year != time.Now().Year() {
-		t.Errorf("Expected current year %d, got %d", time.Now().Year(), config.Year)
+	if config.Year != 2024 {
+		t.Errorf("Expected current year 2024, got %d", config.Year)
 	}
 }
 
@@ -269,6 +288,45 @@ func TestParseArgsFlags(t *testing.T) {
 	}
 }
 
+func TestParseArgsHolidayTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		billable float64
+		wantErr  bool
+	}{
+		{"Bavarian holidays", []string{"billme", "5", "2024", "-x", "--country", "DE", "--region", "BY"}, 19, false},
+		{"Public only, implies -x", []string{"billme", "5", "2024", "--country", "DE", "--region", "BY", "--holiday-types", "public"}, 20, false},
+		{"Unknown type", []string{"billme", "5", "2024", "--holiday-types", "national"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+
+			os.Args = tt.args
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+			config, err := ParseArgs()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ParseArgs() should reject an unknown holiday type")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseArgs() error = %v", err)
+			}
+			period, opts := config.Calculation()
+			result, err := workdays.Calculate(context.Background(), period, opts)
+			if err != nil || result.Billable != tt.billable {
+				t.Errorf("Calculate() = %v billable, %v; want %v", result.Billable, err, tt.billable)
+			}
+		})
+	}
+}
+
 func TestParseArgsValidMonthRange(t *testing.T) {
 	for month := 1; month <= 12; month++ {
 		t.Run(fmt.Sprintf("Month_%d", month), func(t *testing.T) {
@@ -288,3 +346,1634 @@ func TestParseArgsValidMonthRange(t *testing.T) {
 		})
 	}
 }
+
+func TestParseArgsPayment(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "-rate", "6000", "-iban", "CZ6508000000192000145399", "-vs", "20240701", "-due", "2024-08-14", "-qr", "7", "2024"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	config, err := ParseArgs()
+	if err != nil {
+		t.Fatalf("ParseArgs() returned error: %v", err)
+	}
+
+	payment := Payment(23, config)
+	if payment.Amount != 138000 {
+		t.Errorf("Expected amount 138000, got %v", payment.Amount)
+	}
+	if payment.Currency != "CZK" {
+		t.Errorf("Expected default currency CZK, got %s", payment.Currency)
+	}
+	if !payment.DueDate.Equal(time.Date(2024, 8, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected due date 2024-08-14, got %s", payment.DueDate.Format("2006-01-02"))
+	}
+}
+
+func TestParseArgsPaymentInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"QR without IBAN", []string{"-rate", "6000", "-qr"}},
+		{"QR without rate", []string{"-iban", "CZ6508000000192000145399", "-qr-png", "qr.png"}},
+		{"Negative rate", []string{"-rate", "-1"}},
+		{"Invalid due date", []string{"-due", "14.8.2024"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+
+			os.Args = append([]string{"billme"}, tt.args...)
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+			if _, err := ParseArgs(); err == nil {
+				t.Errorf("ParseArgs() should return error for %v", tt.args)
+			}
+		})
+	}
+}
+
+func TestParseArgsSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"rate": 6000, "currency": "EUR", "iban": "CZ6508000000192000145399", "vat_rate": 21,
+		"supplier": {"name": "Jan Novák", "id": "12345678"}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "-config", path, "-rate", "7000", "-exchange-rate", "25", "-isdoc", "invoice.isdoc", "-invoice-number", "20240007", "7", "2024"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	config, err := ParseArgs()
+	if err != nil {
+		t.Fatalf("ParseArgs() returned error: %v", err)
+	}
+
+	if config.Rate != 7000 {
+		t.Errorf("Expected rate from flag 7000, got %v", config.Rate)
+	}
+	if config.Currency != "EUR" || config.IBAN != "CZ6508000000192000145399" || config.VATRate != 21 {
+		t.Errorf("Expected currency, IBAN and VAT from config, got %s %s %v", config.Currency, config.IBAN, config.VATRate)
+	}
+
+	invoice, err := Invoice(22, config, time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Invoice() error = %v", err)
+	}
+	if invoice.Number != "20240007" || invoice.Quantity != 22 || invoice.UnitPrice != 7000 || invoice.ExchangeRate != 25 {
+		t.Errorf("Invoice() = %+v; unexpected line", invoice)
+	}
+	if invoice.Supplier.Name != "Jan Novák" {
+		t.Errorf("Expected supplier from config, got %q", invoice.Supplier.Name)
+	}
+	if !invoice.TaxPointDate.Equal(time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected tax point 2024-07-31, got %s", invoice.TaxPointDate.Format("2006-01-02"))
+	}
+	if !invoice.DueDate.Equal(time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected default due date 2024-08-15, got %s", invoice.DueDate.Format("2006-01-02"))
+	}
+}
+
+func TestParseArgsRecordedVacation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"vacation": ["2024-06-28", "2024-07-08..2024-07-09"]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	tests := []struct {
+		name     string
+		args     []string
+		vacation int
+	}{
+		{"Recorded in the billed month", []string{"-config", path, "7", "2024"}, 2},
+		{"Recorded in other months", []string{"-config", path, "8", "2024"}, 0},
+		{"Flag takes precedence", []string{"-config", path, "--vacation", "2024-07-22", "7", "2024"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Args = append([]string{"billme"}, tt.args...)
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+			config, err := ParseArgs()
+			if err != nil {
+				t.Fatalf("ParseArgs() returned error: %v", err)
+			}
+			if len(config.Vacation) != tt.vacation {
+				t.Errorf("ParseArgs() vacation = %v; want %d dates", config.Vacation, tt.vacation)
+			}
+		})
+	}
+}
+
+func TestParseArgsISDOCRequiresRate(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "-isdoc", "invoice.isdoc", "7", "2024"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	if _, err := ParseArgs(); err == nil {
+		t.Error("ParseArgs() should require --rate with --isdoc")
+	}
+}
+
+func TestParseArgsISDOCRequiresExchangeRate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"Invoice in CZK", []string{"-currency", "czk"}, false},
+		{"Foreign currency without a rate", []string{"-currency", "EUR"}, true},
+		{"Foreign currency with a rate", []string{"-currency", "EUR", "-exchange-rate", "25.125"}, false},
+		{"Negative rate", []string{"-currency", "EUR", "-exchange-rate", "-1"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Args = append([]string{"billme", "-config", path, "-isdoc", "invoice.isdoc", "-rate", "250"}, append(tt.args, "7", "2024")...)
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+			if _, err := ParseArgs(); (err != nil) != tt.wantErr {
+				t.Errorf("ParseArgs() error = %v; wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseArgsClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"customer": {"name": "Default s.r.o."}, "clients": {"acme": {"name": "ACME s.r.o.", "prefix": "AC"}}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "-config", path, "-client", "acme", "7", "2024"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	config, err := ParseArgs()
+	if err != nil {
+		t.Fatalf("ParseArgs() returned error: %v", err)
+	}
+	if invoice, _ := Invoice(22, config, time.Now()); invoice.Customer.Name != "ACME s.r.o." {
+		t.Errorf("Expected customer ACME s.r.o., got %q", invoice.Customer.Name)
+	}
+
+	os.Args = []string{"billme", "-config", path, "-client", "initech", "7", "2024"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	if _, err := ParseArgs(); err == nil {
+		t.Error("ParseArgs() should fail for an unknown client")
+	}
+}
+
+func TestParseNumberArgs(t *testing.T) {
+	config, err := ParseNumberArgs([]string{"void", "20240007", "--reason", "wrong amount", "--date", "2024-07-31"})
+	if err != nil {
+		t.Fatalf("ParseNumberArgs() returned error: %v", err)
+	}
+	if config.Action != "void" || config.Number != "20240007" || config.Reason != "wrong amount" {
+		t.Errorf("ParseNumberArgs() = %+v; unexpected values", config)
+	}
+	if !config.Date.Equal(time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected date 2024-07-31, got %s", config.Date.Format("2006-01-02"))
+	}
+	if config.StateFile == "" {
+		t.Error("Expected a default state file")
+	}
+}
+
+func TestParseNumberArgsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"Missing action", []string{}},
+		{"Unknown action", []string{"burn"}},
+		{"Void without number", []string{"void", "--reason", "typo"}},
+		{"Void without reason", []string{"void", "20240007"}},
+		{"Next with extra argument", []string{"next", "20240007"}},
+		{"Invalid date", []string{"next", "--date", "31.7.2024"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseNumberArgs(tt.args); err == nil {
+				t.Errorf("ParseNumberArgs(%v) should return error", tt.args)
+			}
+		})
+	}
+}
+
+func TestParseDueArgs(t *testing.T) {
+	config, err := ParseDueArgs([]string{"--issued", "2024-07-31", "--net", "10", "--business"})
+	if err != nil {
+		t.Fatalf("ParseDueArgs() returned error: %v", err)
+	}
+	if !config.Issued.Equal(time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)) || config.Net != 10 || !config.Business {
+		t.Errorf("ParseDueArgs() = %+v; unexpected values", config)
+	}
+
+	config, err = ParseDueArgs(nil)
+	if err != nil {
+		t.Fatalf("ParseDueArgs() returned error: %v", err)
+	}
+	if config.Net != 14 || config.Business || config.Country != "CZ" {
+		t.Errorf("ParseDueArgs() defaults = %+v; want 14 calendar days in CZ", config)
+	}
+}
+
+func TestParseDueArgsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"Invalid issue date", []string{"--issued", "31.7.2024"}},
+		{"Negative terms", []string{"--net", "-5"}},
+		{"Unexpected argument", []string{"2024-07-31"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseDueArgs(tt.args); err == nil {
+				t.Errorf("ParseDueArgs(%v) should return error", tt.args)
+			}
+		})
+	}
+}
+
+func TestFormatDueDate(t *testing.T) {
+	due := time.Date(2024, 8, 14, 0, 0, 0, 0, time.UTC)
+	config := &DueConfig{Issued: time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC), Net: 10, Business: true}
+
+	if result := FormatDueDate(due, config); result != "2024-08-14" {
+		t.Errorf("FormatDueDate() = %q; want %q", result, "2024-08-14")
+	}
+
+	config.Verbose = true
+	expected := "Issued 2024-07-31 + 10 business days: due Wednesday 2024-08-14 📅"
+	if result := FormatDueDate(due, config); result != expected {
+		t.Errorf("FormatDueDate() = %q; want %q", result, expected)
+	}
+}
+
+func TestParseShiftArgs(t *testing.T) {
+	config, err := ParseShiftArgs([]string{"2024-07-08", "-5", "--work-week", "mon-thu"})
+	if err != nil {
+		t.Fatalf("ParseShiftArgs() returned error: %v", err)
+	}
+	if !config.Date.Equal(time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)) || config.Days != -5 {
+		t.Errorf("ParseShiftArgs() = %+v; unexpected values", config)
+	}
+	if config.WorkWeek.String() != "mon,tue,wed,thu" {
+		t.Errorf("Expected work week mon-thu, got %s", config.WorkWeek)
+	}
+
+	for _, args := range [][]string{{"2024-07-08"}, {"8.7.2024", "5"}, {"2024-07-08", "five"}, {"2024-07-08", "5", "--work-week", "xyz"}} {
+		if _, err := ParseShiftArgs(args); err == nil {
+			t.Errorf("ParseShiftArgs(%v) should return error", args)
+		}
+	}
+}
+
+func TestParseNthArgs(t *testing.T) {
+	config, err := ParseNthArgs([]string{"-2", "12", "2024", "--country", "CZ"})
+	if err != nil {
+		t.Fatalf("ParseNthArgs() returned error: %v", err)
+	}
+	if config.N != -2 || config.Month != 12 || config.Year != 2024 {
+		t.Errorf("ParseNthArgs() = %+v; unexpected values", config)
+	}
+	if config.WorkWeek.String() != "mon,tue,wed,thu,fri" {
+		t.Errorf("Expected default work week, got %s", config.WorkWeek)
+	}
+
+	for _, args := range [][]string{{}, {"0"}, {"first"}, {"1", "13"}} {
+		if _, err := ParseNthArgs(args); err == nil {
+			t.Errorf("ParseNthArgs(%v) should return error", args)
+		}
+	}
+}
+
+func TestParseLastWorkdayArgs(t *testing.T) {
+	config, err := ParseLastWorkdayArgs([]string{"--ignore-holidays", "7", "2024"})
+	if err != nil {
+		t.Fatalf("ParseLastWorkdayArgs() returned error: %v", err)
+	}
+	if config.N != -1 || config.Month != 7 || config.Year != 2024 || !config.IgnoreHolidays {
+		t.Errorf("ParseLastWorkdayArgs() = %+v; unexpected values", config)
+	}
+}
+
+func TestParseArgsRemainingUsesClock(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "--remaining"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	config, err := ParseArgs()
+	if err != nil {
+		t.Fatalf("ParseArgs() error: %v", err)
+	}
+	if !config.Remaining || config.Elapsed {
+		t.Errorf("Remaining = %v, Elapsed = %v", config.Remaining, config.Elapsed)
+	}
+	if config.Month != 7 || config.Year != 2024 {
+		t.Errorf("Expected 7/2024 from the clock, got %d/%d", config.Month, config.Year)
+	}
+	if want := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC); !config.Today.Equal(want) {
+		t.Errorf("Today = %v, want %v", config.Today, want)
+	}
+}
+
+func TestParseArgsRemainingAndElapsed(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "--remaining", "--elapsed"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	if _, err := ParseArgs(); err == nil {
+		t.Error("ParseArgs() should reject --remaining together with --elapsed")
+	}
+}
+
+func TestFormatProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *Config
+		expected string
+	}{
+		{
+			name:     "Remaining default format",
+			config:   &Config{Remaining: true},
+			expected: "💰 13",
+		},
+		{
+			name:     "Elapsed invoice ready",
+			config:   &Config{Elapsed: true, InvoiceReady: true},
+			expected: "9",
+		},
+		{
+			name:     "Verbose progress",
+			config:   &Config{Remaining: true, Verbose: true, Month: 7, Year: 2024},
+			expected: "July 2024: 9 of 22 billable days elapsed, 13 remaining [████████░░░░░░░░░░░░] 40% 💸",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatProgress(9, 13, tt.config)
+			if result != tt.expected {
+				t.Errorf("FormatProgress() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseArgsToday(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	tests := []struct {
+		name  string
+		args  []string
+		env   string
+		month int
+		year  int
+	}{
+		{name: "Flag", args: []string{"--today", "2023-02-10"}, month: 2, year: 2023},
+		{name: "Environment", env: "2022-11-30", month: 11, year: 2022},
+		{name: "Flag wins", args: []string{"--today", "2023-02-10"}, env: "2022-11-30", month: 2, year: 2023},
+		{name: "Month argument keeps today's year", args: []string{"--today", "2023-02-10", "7"}, month: 7, year: 2023},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(clock.EnvToday, tt.env)
+			os.Args = append([]string{"billme"}, tt.args...)
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+			config, err := ParseArgs()
+			if err != nil {
+				t.Fatalf("ParseArgs() error: %v", err)
+			}
+			if config.Month != tt.month || config.Year != tt.year {
+				t.Errorf("Expected %d/%d, got %d/%d", tt.month, tt.year, config.Month, config.Year)
+			}
+		})
+	}
+}
+
+func TestParseArgsInvalidToday(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "--today", "yesterday"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	if _, err := ParseArgs(); err == nil {
+		t.Error("ParseArgs() should reject an invalid --today date")
+	}
+}
+
+func TestSubcommandsUseToday(t *testing.T) {
+	t.Setenv(clock.EnvToday, "2023-02-10")
+	want := time.Date(2023, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	due, err := ParseDueArgs(nil)
+	if err != nil {
+		t.Fatalf("ParseDueArgs() error: %v", err)
+	}
+	if !due.Issued.Equal(want) {
+		t.Errorf("due: Issued = %s, want %s", due.Issued.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+
+	number, err := ParseNumberArgs([]string{"list"})
+	if err != nil {
+		t.Fatalf("ParseNumberArgs() error: %v", err)
+	}
+	if !number.Date.Equal(want) {
+		t.Errorf("number: Date = %s, want %s", number.Date.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+
+	last, err := ParseLastWorkdayArgs([]string{"--today", "2021-05-03"})
+	if err != nil {
+		t.Fatalf("ParseLastWorkdayArgs() error: %v", err)
+	}
+	if last.Month != 5 || last.Year != 2021 {
+		t.Errorf("last-workday: got %d/%d, want 5/2021", last.Month, last.Year)
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	now := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		args  []string
+		start monthYear
+		end   monthYear
+	}{
+		{"Current month", nil, monthYear{1, 2024}, monthYear{1, 2024}},
+		{"English name", []string{"July"}, monthYear{7, 2024}, monthYear{7, 2024}},
+		{"English abbreviation", []string{"sept", "2023"}, monthYear{9, 2023}, monthYear{9, 2023}},
+		{"Czech name", []string{"červenec"}, monthYear{7, 2024}, monthYear{7, 2024}},
+		{"Czech genitive", []string{"července", "2023"}, monthYear{7, 2023}, monthYear{7, 2023}},
+		{"Czech abbreviation", []string{"čec", "2024"}, monthYear{7, 2024}, monthYear{7, 2024}},
+		{"Czech without diacritics", []string{"rijen"}, monthYear{10, 2024}, monthYear{10, 2024}},
+		{"Unambiguous prefix", []string{"listop"}, monthYear{11, 2024}, monthYear{11, 2024}},
+		{"ISO month", []string{"2023-07"}, monthYear{7, 2023}, monthYear{7, 2023}},
+		{"Last month crosses the year", []string{"last"}, monthYear{12, 2023}, monthYear{12, 2023}},
+		{"Next month", []string{"next"}, monthYear{2, 2024}, monthYear{2, 2024}},
+		{"Czech keyword", []string{"minulý"}, monthYear{12, 2023}, monthYear{12, 2023}},
+		{"Negative offset", []string{"-1"}, monthYear{12, 2023}, monthYear{12, 2023}},
+		{"Positive offset", []string{"+13"}, monthYear{2, 2025}, monthYear{2, 2025}},
+		{"ISO range", []string{"2024-07..2024-09"}, monthYear{7, 2024}, monthYear{9, 2024}},
+		{"Range across years", []string{"2023-11..2024-02"}, monthYear{11, 2023}, monthYear{2, 2024}},
+		{"Named range with year", []string{"jul..sep", "2023"}, monthYear{7, 2023}, monthYear{9, 2023}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parsePeriod(tt.args, now)
+			if err != nil {
+				t.Fatalf("parsePeriod(%v) error: %v", tt.args, err)
+			}
+			if start != tt.start || end != tt.end {
+				t.Errorf("parsePeriod(%v) = %v..%v, want %v..%v", tt.args, start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestParsePeriodErrors(t *testing.T) {
+	now := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"Typo suggests month", []string{"julz"}, "invalid month: julz (did you mean july?)"},
+		{"Czech typo suggests month", []string{"listopd"}, "invalid month: listopd (did you mean listopad?)"},
+		{"Typo suggests keyword", []string{"nxt"}, "invalid month: nxt (did you mean next?)"},
+		{"Nothing close", []string{"abc"}, "invalid month: abc"},
+		{"Ambiguous prefix", []string{"červ"}, "invalid month: červ (did you mean june or july?)"},
+		{"Month out of range", []string{"13"}, "invalid month: 13 (months are 1-12)"},
+		{"ISO month out of range", []string{"2024-13"}, "invalid month: 2024-13 (months are 1-12)"},
+		{"Year given twice", []string{"2024-07", "2024"}, "2024-07 already includes the year"},
+		{"Backwards range", []string{"nov..jan"}, "range ends before it starts: nov..jan (use YYYY-MM..YYYY-MM to span years)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parsePeriod(tt.args, now)
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("parsePeriod(%v) error = %v, want %q", tt.args, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseArgsMonthRange(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "2024-07..2024-09", "-v"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	config, err := ParseArgs()
+	if err != nil {
+		t.Fatalf("ParseArgs() error: %v", err)
+	}
+	if !config.IsRange() || config.EndMonth != 9 || config.EndYear != 2024 || !config.Verbose {
+		t.Errorf("ParseArgs() = %+v; want July–September 2024, verbose", config)
+	}
+	if result := FormatOutput(65, config); result != "July–September 2024: 65 billable days 💸" {
+		t.Errorf("FormatOutput() = %q", result)
+	}
+
+	os.Args = []string{"billme", "2024-07..2024-09", "--remaining"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	if _, err := ParseArgs(); err == nil {
+		t.Error("ParseArgs() should reject --remaining with a month range")
+	}
+}
+
+func TestParseArgsPreviousMonth(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "-x", "-1"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	config, err := ParseArgs()
+	if err != nil {
+		t.Fatalf("ParseArgs() error: %v", err)
+	}
+	if config.Month != 6 || config.Year != 2024 || !config.ExcludeHolidays {
+		t.Errorf("ParseArgs() = %d/%d; want 6/2024", config.Month, config.Year)
+	}
+}
+
+func TestInvoiceMonthRange(t *testing.T) {
+	config := &Config{Month: 11, Year: 2024, EndMonth: 1, EndYear: 2025, Rate: 100, Currency: "CZK"}
+
+	invoice, err := Invoice(62, config, time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Invoice() error = %v", err)
+	}
+	if invoice.Description != "Services 11/2024–01/2025" {
+		t.Errorf("Description = %q", invoice.Description)
+	}
+	if !invoice.TaxPointDate.Equal(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected tax point 2025-01-31, got %s", invoice.TaxPointDate.Format("2006-01-02"))
+	}
+}
+
+func TestInvoiceDueDate(t *testing.T) {
+	issued := time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		country  string
+		region   string
+		expected string
+	}{
+		{"Default country", "", "", "2024-05-30"},
+		{"Bavaria", "DE", "BY", "2024-05-31"}, // past Corpus Christi on the 30th
+		{"Berlin", "DE", "BE", "2024-05-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Month: 5, Year: 2024, Country: tt.country, Region: tt.region, Rate: 100, Currency: "CZK"}
+			invoice, err := Invoice(10, config, issued)
+			if err != nil {
+				t.Fatalf("Invoice() error = %v", err)
+			}
+			due, err := ParseDueArgs([]string{"--issued", "2024-05-16", "--country", cmp.Or(tt.country, "CZ"), "--region", tt.region})
+			if err != nil {
+				t.Fatalf("ParseDueArgs() error = %v", err)
+			}
+			calendar, err := due.Calendar()
+			if err != nil {
+				t.Fatalf("Calendar() error = %v", err)
+			}
+			expected := calculator.DueDate(calendar, due.Issued, due.Net, false)
+			if got := invoice.DueDate.Format(time.DateOnly); got != tt.expected || !invoice.DueDate.Equal(expected) {
+				t.Errorf("Invoice() due %s, billme due %s; want %s", got, expected.Format(time.DateOnly), tt.expected)
+			}
+		})
+	}
+
+	config := &Config{Month: 5, Year: 2024, Country: "DE", Region: "XX", Rate: 100, Currency: "CZK"}
+	if _, err := Invoice(10, config, issued); ExitCode(err, ExitError) != ExitUnknownCountry {
+		t.Errorf("Invoice() error = %v; want an unknown region", err)
+	}
+}
+
+// useLanguage switches the package to lang for the rest of the test.
+func useLanguage(t *testing.T, lang i18n.Lang) {
+	t.Helper()
+	old := tr
+	tr = i18n.NewPrinter(lang, catalog)
+	t.Cleanup(func() { tr = old })
+}
+
+func TestFormatOutputCzech(t *testing.T) {
+	useLanguage(t, i18n.Czech)
+
+	tests := []struct {
+		name        string
+		workingDays int
+		config      *Config
+		expected    string
+	}{
+		{"One day", 1, &Config{Verbose: true, Month: 7, Year: 2024}, "Červenec 2024: 1 fakturovatelný den 💸"},
+		{"Few days", 3, &Config{Verbose: true, Month: 7, Year: 2024}, "Červenec 2024: 3 fakturovatelné dny 💸"},
+		{"Many days", 22, &Config{Verbose: true, Month: 7, Year: 2024}, "Červenec 2024: 22 fakturovatelných dní 💸"},
+		{"Range", 65, &Config{Verbose: true, Month: 7, Year: 2024, EndMonth: 9, EndYear: 2024}, "Červenec–září 2024: 65 fakturovatelných dní 💸"},
+		{"Ka-ching", 2, &Config{KaChing: true}, "2 dny = CHA-CHING! 🤑"},
+		{"Default", 22, &Config{}, "💰 22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := FormatOutput(tt.workingDays, tt.config); result != tt.expected {
+				t.Errorf("FormatOutput() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatOutputEnglishSingular(t *testing.T) {
+	if result := FormatOutput(1, &Config{Verbose: true, Month: 7, Year: 2024}); result != "July 2024: 1 billable day 💸" {
+		t.Errorf("FormatOutput() = %q", result)
+	}
+	if result := FormatOutput(1, &Config{KaChing: true}); result != "1 day = CHA-CHING! 🤑" {
+		t.Errorf("FormatOutput() = %q", result)
+	}
+}
+
+func TestFormatProgressCzech(t *testing.T) {
+	useLanguage(t, i18n.Czech)
+
+	config := &Config{Remaining: true, Verbose: true, Month: 7, Year: 2024}
+	expected := "V červenci 2024 uplynuly 2 z 22 fakturovatelných dní, zbývá 20 [█░░░░░░░░░░░░░░░░░░░] 9 % 💸"
+	if result := FormatProgress(2, 20, config); result != expected {
+		t.Errorf("FormatProgress() = %q, want %q", result, expected)
+	}
+}
+
+func TestFormatDueDateCzech(t *testing.T) {
+	useLanguage(t, i18n.Czech)
+
+	config := &DueConfig{Issued: time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC), Net: 10, Business: true, Verbose: true}
+	expected := "Vystaveno 2024-07-31 + 10 pracovních dní: splatnost středa 2024-08-14 📅"
+	if result := FormatDueDate(time.Date(2024, 8, 14, 0, 0, 0, 0, time.UTC), config); result != expected {
+		t.Errorf("FormatDueDate() = %q, want %q", result, expected)
+	}
+}
+
+func TestTooFewWorkingDays(t *testing.T) {
+	config := &NthConfig{N: -25, Month: 7, Year: 2024}
+	if err := TooFewWorkingDays(config); err.Error() != "July 2024 has fewer than 25 working days" {
+		t.Errorf("TooFewWorkingDays() = %q", err)
+	}
+
+	useLanguage(t, i18n.Czech)
+	if err := TooFewWorkingDays(config); err.Error() != "V červenci 2024 je méně než 25 pracovních dní" {
+		t.Errorf("TooFewWorkingDays() = %q", err)
+	}
+}
+
+func TestParseArgsLanguage(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	useLanguage(t, i18n.English)
+
+	t.Setenv("LANG", "cs_CZ.UTF-8")
+	os.Args = []string{"billme", "julz"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	_, err := ParseArgs()
+	if err == nil || err.Error() != "neplatný měsíc: julz (neměli jste na mysli july?)" {
+		t.Errorf("ParseArgs() error = %v; want Czech message from LANG", err)
+	}
+
+	os.Args = []string{"billme", "--lang", "en", "julz"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	_, err = ParseArgs()
+	if err == nil || err.Error() != "invalid month: julz (did you mean july?)" {
+		t.Errorf("ParseArgs() error = %v; want English message from --lang", err)
+	}
+
+	os.Args = []string{"billme", "--lang", "de"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	if _, err := ParseArgs(); err == nil {
+		t.Error("ParseArgs() should reject an unsupported --lang")
+	}
+}
+
+func TestCzechCatalogComplete(t *testing.T) {
+	english := catalog[i18n.English].Plural
+	czech := catalog[i18n.Czech].Plural
+	for id := range english {
+		if len(czech[id]) != 3 {
+			t.Errorf("Czech plural %q has %d forms, want 3", id, len(czech[id]))
+		}
+	}
+	for _, help := range []string{mainHelp, usageHint, dueHelp, numberHelp, workdaysHelp, holidaysHelp, icsHelp, isWorkdayHelp} {
+		if _, ok := catalog[i18n.Czech].Text[help]; !ok {
+			t.Errorf("missing Czech help text for %q", help[:20])
+		}
+	}
+}
+
+func TestFormatReport(t *testing.T) {
+	config := &Config{Month: 7, Year: 2024, VacationDays: 2, Rate: 6250.5, Currency: "CZK", ExcludeHolidays: true}
+	report := NewReport(config, 22, 20, 9, 11)
+
+	tests := []struct {
+		name     string
+		format   string
+		named    map[string]string
+		expected string
+	}{
+		{"Fields", "{{.Month}} {{.Year}}: {{.Days}}d ({{.Amount}})", nil, "7 2024: 20d (125010)"},
+		{"Period and money", "{{.Period}}: {{money .Amount .Currency}}", nil, "July 2024: 125,010.00 CZK"},
+		{"Money without currency", "{{money .Rate}}", nil, "6,250.50"},
+		{"Pad right", "[{{pad 4 .Days}}]", nil, "[  20]"},
+		{"Pad left", "[{{pad -4 .Days}}]", nil, "[20  ]"},
+		{"Month name", "{{monthName .Month}}", nil, "July"},
+		{"Split", "{{.WorkingDays}} {{.VacationDays}} {{.Elapsed}}/{{.Remaining}}", nil, "22 2 9/11"},
+		{"Named template", "status", map[string]string{"status": "💰{{.Days}}"}, "💰20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parseFormat(tt.format, tt.named)
+			if err != nil {
+				t.Fatalf("parseFormat() error: %v", err)
+			}
+			result, err := FormatReport(tmpl, report)
+			if err != nil {
+				t.Fatalf("FormatReport() error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("FormatReport() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseFormatErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{"Typo in field", "{{.Dayz}}", "invalid format: unknown field .Dayz (did you mean .Days?)"},
+		{"Unknown function", "{{sum .Days}}", "invalid format: unknown function sum (available: money, monthName, pad)"},
+		{"Syntax error", "{{.Days", "invalid format at line 1: unclosed action"},
+		{"Helper error", "{{monthName 13}}", "invalid format at line 1: error calling monthName: invalid month: 13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFormat(tt.format, nil)
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("parseFormat() error = %v, want %q", err, tt.expected)
+			}
+		})
+	}
+
+	_, err := parseFormat("{{.Nope}}", nil)
+	if err == nil || !strings.Contains(err.Error(), "available: .Month, .Year") {
+		t.Errorf("parseFormat() error = %v, want the list of fields", err)
+	}
+}
+
+func TestFormatMoneyCzech(t *testing.T) {
+	useLanguage(t, i18n.Czech)
+
+	if result := formatMoney(1234567.891, "CZK"); result != "1 234 567,89 CZK" {
+		t.Errorf("formatMoney() = %q", result)
+	}
+	if result := formatMoney(-50, ""); result != "-50,00" {
+		t.Errorf("formatMoney() = %q", result)
+	}
+}
+
+func TestParseArgsFormat(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"billme", "--format", "{{.Days}}", "7", "2024"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	config, err := ParseArgs()
+	if err != nil || config.Format == nil {
+		t.Fatalf("ParseArgs() = %v, %v; want a format template", config, err)
+	}
+
+	os.Args = []string{"billme", "--format", "{{.Foo}}"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	if _, err := ParseArgs(); err == nil {
+		t.Error("ParseArgs() should reject a template with an unknown field")
+	}
+}
+
+func TestParseDates(t *testing.T) {
+	dates, err := parseDates("2024-07-08..2024-07-10, 2024-07-22")
+	if err != nil {
+		t.Fatalf("parseDates() error = %v", err)
+	}
+	var got []string
+	for _, date := range dates {
+		got = append(got, date.Format("2006-01-02"))
+	}
+	want := "2024-07-08 2024-07-09 2024-07-10 2024-07-22"
+	if strings.Join(got, " ") != want {
+		t.Errorf("parseDates() = %v, want %s", got, want)
+	}
+
+	for _, value := range []string{"2024-07-32", "2024-07-10..2024-07-08", "july"} {
+		if _, err := parseDates(value); err == nil {
+			t.Errorf("parseDates(%q) should fail", value)
+		}
+	}
+}
+
+func TestParseArgsOutput(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	tests := []struct {
+		name  string
+		args  []string
+		valid bool
+	}{
+		{"Markdown", []string{"--output", "markdown", "7", "2024"}, true},
+		{"HTML with vacation", []string{"--output", "html", "--vacation", "2024-07-08..2024-07-09", "7", "2024"}, true},
+		{"Unknown output", []string{"--output", "pdf", "7", "2024"}, false},
+		{"With format", []string{"--output", "html", "--format", "{{.Days}}", "7", "2024"}, false},
+		{"With remaining", []string{"--output", "html", "--remaining"}, false},
+		{"Vacation outside the month", []string{"--vacation", "2024-08-01", "7", "2024"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Args = append([]string{"billme"}, tt.args...)
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+			_, err := ParseArgs()
+			if (err == nil) != tt.valid {
+				t.Errorf("ParseArgs(%v) error = %v, want valid %v", tt.args, err, tt.valid)
+			}
+		})
+	}
+}
+
+func calculate(t *testing.T, config *Config) workdays.Result {
+	t.Helper()
+	period, opts := config.Calculation()
+	result, err := workdays.Calculate(context.Background(), period, opts)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	return result
+}
+
+func TestFormatDocumentMarkdown(t *testing.T) {
+	config := &Config{Month: 7, Year: 2024, Rate: 1000, Currency: "CZK", ExcludeHolidays: true,
+		Vacation: []time.Time{time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)}}
+	output, err := FormatDocument(OutputMarkdown, NewReportData(calculate(t, config), config))
+	if err != nil {
+		t.Fatalf("FormatDocument() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# Billable days report: July 2024",
+		"| Working days | 22 |",
+		"| Public holidays | 1 |",
+		"| Vacation days | 1 |",
+		"| **Billable days** | **21** |",
+		"| **Amount** | **21,000.00 CZK** |",
+		"| 1 | 2 | 3 | 4 | 5 🎉 | _6_ | _7_ |",
+		"| 8 🌴 | 9 |",
+		"| 29 | 30 | 31 |  |  |  |  |",
+		"- Friday 2024-07-05: St. Cyril and Methodius Day",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatDocument() is missing %q in:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatDocumentHTML(t *testing.T) {
+	useLanguage(t, i18n.Czech)
+
+	config := &Config{Month: 7, Year: 2024, ExcludeHolidays: true}
+	output, err := FormatDocument(OutputHTML, NewReportData(calculate(t, config), config))
+	if err != nil {
+		t.Fatalf("FormatDocument() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`<html lang="cs">`,
+		"<style>",
+		"<h1>Přehled fakturovatelných dní: červenec 2024</h1>",
+		`<td class="holiday" title="Den slovanských věrozvěstů Cyrila a Metoděje">5</td>`,
+		`<td class="weekend" title="Den upálení mistra Jana Husa">6</td>`,
+		"<th>Po</th>",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatDocument() is missing %q in:\n%s", want, output)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"Invalid month", &calculator.MonthError{Month: 13}, ExitInvalidMonth},
+		{"Year out of range", &calculator.YearError{Year: 1500}, ExitYearOutOfRange},
+		{"Unknown country", fmt.Errorf("due: %w", &calculator.CountryError{Country: "XX"}), ExitUnknownCountry},
+		{"Negative vacation", &calculator.VacationError{Days: -1}, ExitInvalidVacation},
+		{"Unknown region", &calculator.RegionError{Country: "DE", Region: "XX"}, ExitUnknownCountry},
+		{"Localized", Localize(&calculator.YearError{Year: 1500}), ExitYearOutOfRange},
+		{"Other error", fmt.Errorf("boom"), ExitUsage},
+		{"Failure of is-workday", &workdayError{fmt.Errorf("boom")}, ExitWorkdayFailure},
+		{"Typed failure of is-workday", &workdayError{&calculator.YearError{Year: 1500}}, ExitYearOutOfRange},
+		{"Invalid holiday type", Localize(&holidays.HolidayTypeError{Type: "publik"}), ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := ExitCode(tt.err, ExitUsage); code != tt.expected {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseArgsInvalidMonthExitCode(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	tests := []struct {
+		args     []string
+		expected int
+	}{
+		{[]string{"13", "2024"}, ExitInvalidMonth},
+		{[]string{"2024-00"}, ExitInvalidMonth},
+		{[]string{"julz"}, ExitInvalidMonth},
+		{[]string{"7", "abc"}, ExitUsage},
+		{[]string{"7", "2024", "--holiday-types", "publik"}, ExitUsage},
+	}
+
+	for _, tt := range tests {
+		os.Args = append([]string{"billme"}, tt.args...)
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+		_, err := ParseArgs()
+		if code := ExitCode(err, ExitUsage); code != tt.expected {
+			t.Errorf("ParseArgs(%v) error %v exits %d, want %d", tt.args, err, code, tt.expected)
+		}
+	}
+}
+
+func TestLocalizeCzech(t *testing.T) {
+	useLanguage(t, i18n.Czech)
+
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&calculator.YearError{Year: 1500}, "rok mimo rozsah: 1500 (podporováno 1583–4099)"},
+		{&calculator.CountryError{Country: "XX"}, "neznámá země: XX"},
+		{&calculator.RegionError{Country: "CZ", Region: "PHA"}, "CZ nemá regionální svátky"},
+		{&calculator.RegionError{Country: "DE", Region: "XX", Regions: []string{"BE", "BY"}}, "neznámý region země DE: XX (použijte BE, BY)"},
+		{&calculator.ExcessVacationError{VacationDays: 30, WorkingDays: 22}, "dny dovolené (30) přesahují pracovní dny (22); fakturuje se 0"},
+		{&holidays.HolidayTypeError{Type: "publik", Types: []string{"public", "bank"}}, "neplatný druh svátku: publik (použijte public, bank nebo all)"},
+		{&clock.DateError{Value: "15.7.2024"}, "neplatné dnešní datum: 15.7.2024"},
+		{fmt.Errorf("other"), "other"},
+	}
+
+	for _, tt := range tests {
+		if got := Localize(tt.err).Error(); got != tt.expected {
+			t.Errorf("Localize(%v) = %q, want %q", tt.err, got, tt.expected)
+		}
+	}
+}
+
+func TestParseHolidaysArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		year     int
+		exitCode int // 0 when valid
+	}{
+		{"Current year", nil, 2024, 0},
+		{"Explicit year", []string{"2025", "--output", "csv"}, 2025, 0},
+		{"Invalid year", []string{"next"}, 0, ExitUsage},
+		{"Year out of range", []string{"1500"}, 0, ExitYearOutOfRange},
+		{"Unknown output", []string{"--output", "xml"}, 0, ExitUsage},
+		{"Too many arguments", []string{"2024", "2025"}, 0, ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseHolidaysArgs(tt.args)
+			if tt.exitCode != 0 {
+				if code := ExitCode(err, ExitUsage); err == nil || code != tt.exitCode {
+					t.Errorf("ParseHolidaysArgs(%v) error = %v, exit %d; want exit %d", tt.args, err, code, tt.exitCode)
+				}
+				return
+			}
+			if err != nil || config.Year != tt.year {
+				t.Errorf("ParseHolidaysArgs(%v) = %v, %v; want year %d", tt.args, config, err, tt.year)
+			}
+		})
+	}
+}
+
+func TestHolidaysErrors(t *testing.T) {
+	if _, err := Holidays(&HolidaysConfig{CalendarConfig: CalendarConfig{Country: "XX"}, Year: 2024}); ExitCode(err, 0) != ExitUnknownCountry {
+		t.Errorf("Holidays(XX) error = %v; want an unknown country", err)
+	}
+	if _, err := Holidays(&HolidaysConfig{CalendarConfig: CalendarConfig{Country: "CZ", Region: "PHA"}, Year: 2024}); err == nil {
+		t.Error("Holidays() should reject a region for a country without regional holidays")
+	}
+	if _, err := Holidays(&HolidaysConfig{CalendarConfig: CalendarConfig{Country: "DE", Region: "XX"}, Year: 2024}); ExitCode(err, 0) != ExitUnknownCountry {
+		t.Errorf("Holidays(DE-XX) error = %v; want an unknown region", err)
+	}
+}
+
+func holidaysOutput(t *testing.T, output string) string {
+	t.Helper()
+	config, err := ParseHolidaysArgs([]string{"2024", "--output", output})
+	if err != nil {
+		t.Fatalf("ParseHolidaysArgs() error = %v", err)
+	}
+	list, err := Holidays(config)
+	if err != nil {
+		t.Fatalf("Holidays() error = %v", err)
+	}
+	result, err := FormatHolidays(list, config)
+	if err != nil {
+		t.Fatalf("FormatHolidays() error = %v", err)
+	}
+	return result
+}
+
+func TestFormatHolidays(t *testing.T) {
+	tests := []struct {
+		output string
+		want   []string
+	}{
+		{"", []string{
+			"Public holidays 2024 (CZ)",
+			"2024-04-01  Monday      Easter Monday                Velikonoční pondělí                             public  workday       shops closed\n",
+			"2024-07-06  Saturday    Jan Hus Day                  Den upálení mistra Jana Husa                    public  weekend\n",
+			"9 of 12 fall on workdays",
+		}},
+		{"json", []string{
+			`"date": "2024-07-06"`,
+			`"weekday": "Saturday"`,
+			`"id": "jan-hus-day"`,
+			`"english_name": "Jan Hus Day"`,
+			`"language": "cs"`,
+			`"type": "public"`,
+			`"shops_closed": false`,
+			`"source": "zákon č. 245/2000 Sb., § 1"`,
+			`"workday": false`,
+		}},
+		{"csv", []string{
+			"date,weekday,name,english_name,workday,id,language,type,shops_closed,source,country\n" +
+				"2024-01-01,Monday,Nový rok,New Year's Day,true,new-years-day,cs,public,true,\"zákon č. 245/2000 Sb., § 2\",\n2024-04-01,",
+			"2024-12-26,Thursday,2. svátek vánoční,St. Stephen's Day,true,st-stephens-day,cs,public,true,",
+		}},
+		{"ics", []string{
+			"BEGIN:VCALENDAR\r\n",
+			"UID:20240706-cz-jan-hus-day@billme\r\n",
+			"DTSTART;VALUE=DATE:20240706\r\nDTEND;VALUE=DATE:20240707\r\n",
+			"SUMMARY:Jan Hus Day\r\nDESCRIPTION:Den upálení mistra Jana Husa\r\n",
+			"END:VCALENDAR\r\n",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			result := holidaysOutput(t, tt.output)
+			for _, want := range tt.want {
+				if !strings.Contains(result, want) {
+					t.Errorf("FormatHolidays() is missing %q in:\n%s", want, result)
+				}
+			}
+		})
+	}
+}
+
+func TestParseICSArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		start    time.Time
+		end      time.Time
+		exitCode int // 0 when valid
+	}{
+		{"Current year", nil, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 0},
+		{"Explicit year", []string{"2025"}, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), 0},
+		{"Month", []string{"7"}, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC), 0},
+		{"Range of months", []string{"2024-11..2025-02"}, time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), 0},
+		{"Year out of range", []string{"1500"}, time.Time{}, time.Time{}, ExitYearOutOfRange},
+		{"Invalid month", []string{"13"}, time.Time{}, time.Time{}, ExitInvalidMonth},
+		{"Invalid vacation", []string{"--vacation", "2024-07-32"}, time.Time{}, time.Time{}, ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseICSArgs(tt.args)
+			if tt.exitCode != 0 {
+				if code := ExitCode(err, ExitUsage); err == nil || code != tt.exitCode {
+					t.Errorf("ParseICSArgs(%v) error = %v, exit %d; want exit %d", tt.args, err, code, tt.exitCode)
+				}
+				return
+			}
+			if err != nil || !config.Start.Equal(tt.start) || !config.End.Equal(tt.end) {
+				t.Errorf("ParseICSArgs(%v) = %v, %v; want %s..%s", tt.args, config, err, tt.start.Format("2006-01-02"), tt.end.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestICS(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		notWant []string
+	}{
+		{"Holidays and vacation", []string{"7", "--vacation", "2024-07-05..2024-07-08"}, []string{
+			"X-WR-CALNAME:Holidays and vacation (CZ)\r\n",
+			"UID:20240705-cz-cyril-methodius-day@billme\r\n",
+			"UID:20240706-cz-jan-hus-day@billme\r\n",
+			"UID:20240705-vacation@billme\r\n",
+			"UID:20240708-vacation@billme\r\nDTSTAMP:20240715T000000Z\r\nDTSTART;VALUE=DATE:20240708\r\n",
+			"SUMMARY:Vacation\r\n",
+			"TRANSP:OPAQUE\r\n",
+		}, []string{
+			"20240101-cz@billme",
+			"20240706-vacation@billme",
+		}},
+		{"Only holidays", []string{"--no-vacation", "--vacation", "2024-07-08"}, []string{
+			"UID:20240101-cz-new-years-day@billme\r\n",
+			"UID:20241226-cz-st-stephens-day@billme\r\n",
+		}, []string{"vacation@billme"}},
+		{"Only vacation", []string{"--no-holidays", "--vacation", "2024-07-08"}, []string{
+			"UID:20240708-vacation@billme\r\n",
+		}, []string{"-cz@billme"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseICSArgs(tt.args)
+			if err != nil {
+				t.Fatalf("ParseICSArgs() error = %v", err)
+			}
+			result, err := ICS(config)
+			if err != nil {
+				t.Fatalf("ICS() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(result, want) {
+					t.Errorf("ICS() is missing %q in:\n%s", want, result)
+				}
+			}
+			for _, unwanted := range tt.notWant {
+				if strings.Contains(result, unwanted) {
+					t.Errorf("ICS() should not contain %q", unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestICSErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *ICSConfig
+	}{
+		{"Unknown country", &ICSConfig{CalendarConfig: CalendarConfig{Country: "XX"}}},
+		{"Region without regional holidays", &ICSConfig{CalendarConfig: CalendarConfig{Country: "CZ", Region: "PHA"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ICS(tt.config); err == nil {
+				t.Error("ICS() should fail")
+			}
+		})
+	}
+}
+
+func TestFormatHolidaysCzech(t *testing.T) {
+	result := holidaysOutput(t, "")
+	useLanguage(t, i18n.Czech)
+	config := &HolidaysConfig{CalendarConfig: CalendarConfig{Country: "CZ", WorkWeek: calculator.DefaultWorkWeek}, Year: 2024}
+	list, err := Holidays(config)
+	if err != nil {
+		t.Fatalf("Holidays() error = %v", err)
+	}
+	czech, _ := FormatHolidays(list, config)
+
+	if !strings.Contains(result, "Jan Hus Day                  Den upálení") {
+		t.Errorf("English table should name holidays in English first:\n%s", result)
+	}
+	want := "2024-07-06  sobota      Den upálení mistra Jana Husa                    Jan Hus Day                  státní  víkend\n"
+	if !strings.Contains(czech, want) {
+		t.Errorf("Czech table is missing %q in:\n%s", want, czech)
+	}
+}
+
+func TestParseIsWorkdayArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		date     time.Time
+		exitCode int // 0 when valid
+	}{
+		{"Today", nil, time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), 0},
+		{"Explicit date", []string{"2024-07-05", "-v"}, time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC), 0},
+		{"Invalid date", []string{"2024-13-01"}, time.Time{}, ExitUsage},
+		{"Unknown flag", []string{"--weekend"}, time.Time{}, ExitUsage},
+		{"Invalid vacation", []string{"--vacation", "july"}, time.Time{}, ExitUsage},
+		{"Too many arguments", []string{"2024-07-05", "2024-07-06"}, time.Time{}, ExitUsage},
+		{"Invalid holiday type", []string{"--holiday-types", "public,national"}, time.Time{}, ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseIsWorkdayArgs(tt.args)
+			if tt.exitCode != 0 {
+				// The fallback must not be taken: exit 1 means a day off.
+				if code := ExitCode(err, ExitError); err == nil || code != tt.exitCode {
+					t.Errorf("ParseIsWorkdayArgs(%v) error = %v, exit %d; want exit %d", tt.args, err, code, tt.exitCode)
+				}
+				return
+			}
+			if err != nil || !config.Date.Equal(tt.date) {
+				t.Errorf("ParseIsWorkdayArgs(%v) = %v, %v; want %s", tt.args, config, err, tt.date.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestIsWorkday(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		kind     workdays.DayKind
+		expected string
+	}{
+		{"Working day", []string{"2024-07-15"}, workdays.Workday, "2024-07-15 Monday is a working day"},
+		{"Weekend", []string{"2024-07-06"}, workdays.Weekend, "2024-07-06 Saturday is not a working day: weekend"},
+		{"Holiday", []string{"2024-07-05"}, workdays.Holiday,
+			"2024-07-05 Friday is not a working day: public holiday St. Cyril and Methodius Day"},
+		{"Holiday ignored", []string{"2024-07-05", "--ignore-holidays"}, workdays.Workday, "2024-07-05 Friday is a working day"},
+		{"Vacation", []string{"2024-07-08", "--vacation", "2024-07-08..2024-07-12"}, workdays.Vacation,
+			"2024-07-08 Monday is not a working day: vacation"},
+		{"Work week", []string{"2024-07-12", "--work-week", "mon-thu"}, workdays.Weekend,
+			"2024-07-12 Friday is not a working day: weekend"},
+		{"Czech", []string{"2024-07-06", "--lang", "cs"}, workdays.Weekend, "2024-07-06 sobota není pracovní den: víkend"},
+		{"Regional holiday", []string{"2024-05-30", "--country", "DE", "--region", "BY"}, workdays.Holiday,
+			"2024-05-30 Thursday is not a working day: public holiday Corpus Christi"},
+		{"Regional holiday not a day off", []string{"2024-05-30", "--country", "DE", "--region", "BY", "--holiday-types", "public"}, workdays.Workday,
+			"2024-05-30 Thursday is a working day"},
+		{"Special working day", []string{"2024-02-18", "--country", "CN"}, workdays.Workday,
+			"2024-02-18 Sunday is a working day: Spring Festival (working day)"},
+		{"Rest day", []string{"2024-02-16", "--country", "CN"}, workdays.Holiday,
+			"2024-02-16 Friday is not a working day: public holiday Spring Festival (rest day)"},
+		{"Combined countries", []string{"2024-05-01", "--country", "CZ+DE-BY"}, workdays.Holiday,
+			"2024-05-01 Wednesday is not a working day: public holiday Labour Day (CZ), Labour Day (DE-BY)"},
+		{"Holiday in one of combined countries", []string{"2024-05-30", "--country", "CZ+DE-BY"}, workdays.Holiday,
+			"2024-05-30 Thursday is not a working day: public holiday Corpus Christi (DE-BY)"},
+		{"Intersection of countries", []string{"2024-05-30", "--country", "CZ+DE-BY", "--intersect"}, workdays.Workday,
+			"2024-05-30 Thursday is a working day"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useLanguage(t, i18n.English)
+			config, err := ParseIsWorkdayArgs(tt.args)
+			if err != nil {
+				t.Fatalf("ParseIsWorkdayArgs() error = %v", err)
+			}
+			day, err := IsWorkday(config)
+			if err != nil {
+				t.Fatalf("IsWorkday() error = %v", err)
+			}
+			if day.Kind != tt.kind {
+				t.Errorf("IsWorkday() kind = %v, want %v", day.Kind, tt.kind)
+			}
+			if result := FormatWorkday(day); result != tt.expected {
+				t.Errorf("FormatWorkday() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsWorkdayUnknownCountry(t *testing.T) {
+	config, err := ParseIsWorkdayArgs([]string{"--country", "XX"})
+	if err != nil {
+		t.Fatalf("ParseIsWorkdayArgs() error = %v", err)
+	}
+	if _, err := IsWorkday(config); ExitCode(err, ExitError) != ExitUnknownCountry {
+		t.Errorf("IsWorkday() error = %v; want an unknown country", err)
+	}
+}
+
+func TestScheduleWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		period   workdays.Period
+		opts     workdays.Options
+		expected string
+	}{
+		{"Known schedule", workdays.Month(2026, time.October), workdays.Options{Country: "CN", ExcludeHolidays: true}, ""},
+		{"Unknown schedule", workdays.Month(2027, time.October), workdays.Options{Country: "cn", ExcludeHolidays: true},
+			"only statutory holidays are counted for CN in 2027: no schedule of days off is known"},
+		{"Combined", workdays.Months(2021, time.December, 2023, time.January), workdays.Options{Country: "CZ+CN", ExcludeHolidays: true},
+			"only statutory holidays are counted for CZ+CN in 2021, 2022: no schedule of days off is known"},
+		{"Holidays included", workdays.Month(2027, time.October), workdays.Options{Country: "CN"}, ""},
+		{"Country without schedules", workdays.Month(2027, time.October), workdays.Options{Country: "CZ", ExcludeHolidays: true}, ""},
+		{"Unknown country", workdays.Month(2027, time.October), workdays.Options{Country: "XX", ExcludeHolidays: true}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScheduleWarning(tt.period, tt.opts); got != tt.expected {
+				t.Errorf("ScheduleWarning() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScheduleWarningCzech(t *testing.T) {
+	useLanguage(t, i18n.Czech)
+
+	expected := "pro CN v roce 2027 se počítají jen zákonné svátky: rozpis volných dnů není znám"
+	if got := ScheduleWarning(workdays.Month(2027, time.May), workdays.Options{Country: "CN", ExcludeHolidays: true}); got != expected {
+		t.Errorf("ScheduleWarning() = %q, want %q", got, expected)
+	}
+}
+
+func TestParseSubcommandArgsInvalidToday(t *testing.T) {
+	useLanguage(t, i18n.English)
+
+	tests := []struct {
+		name  string
+		parse func() error
+	}{
+		{"is-workday", func() error {
+			_, err := ParseIsWorkdayArgs([]string{"--lang", "cs", "--today", "15.7.2024"})
+			return err
+		}},
+		{"due", func() error {
+			_, err := ParseDueArgs([]string{"--lang", "cs", "--today", "15.7.2024"})
+			return err
+		}},
+		{"holidays", func() error {
+			_, err := ParseHolidaysArgs([]string{"--lang", "cs", "--today", "15.7.2024"})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.parse()
+			if err == nil || err.Error() != "neplatné dnešní datum: 15.7.2024" || ExitCode(err, ExitError) != ExitUsage {
+				t.Errorf("error = %v exits %d; want it in Czech with the usage code", err, ExitCode(err, ExitError))
+			}
+		})
+	}
+}
+
+func TestParseIsWorkdayArgsInvalidHolidayType(t *testing.T) {
+	useLanguage(t, i18n.English)
+
+	_, err := ParseIsWorkdayArgs([]string{"--lang", "cs", "--holiday-types", "publik"})
+	if ExitCode(err, ExitError) != ExitUsage {
+		t.Errorf("ParseIsWorkdayArgs() error = %v exits %d, want %d", err, ExitCode(err, ExitError), ExitUsage)
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), "neplatný druh svátku: publik") {
+		t.Errorf("ParseIsWorkdayArgs() error = %v; want it in Czech", err)
+	}
+}
+
+func TestParsePlanArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		year     int
+		days     int
+		exitCode int // 0 when valid
+	}{
+		{"Current year", nil, 2024, 20, 0},
+		{"Explicit year and days", []string{"2025", "--days", "25"}, 2025, 25, 0},
+		{"Invalid year", []string{"next"}, 0, 0, ExitUsage},
+		{"Year out of range", []string{"1500"}, 0, 0, ExitYearOutOfRange},
+		{"Negative days", []string{"--days", "-1"}, 0, 0, ExitInvalidVacation},
+		{"Too many arguments", []string{"2024", "2025"}, 0, 0, ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParsePlanArgs(tt.args)
+			if tt.exitCode != 0 {
+				if code := ExitCode(err, ExitUsage); err == nil || code != tt.exitCode {
+					t.Errorf("ParsePlanArgs(%v) error = %v, exit %d; want exit %d", tt.args, err, code, tt.exitCode)
+				}
+				return
+			}
+			if err != nil || config.Year != tt.year || config.Days != tt.days {
+				t.Errorf("ParsePlanArgs(%v) = %v, %v; want year %d with %d days", tt.args, config, err, tt.year, tt.days)
+			}
+		})
+	}
+}
+
+func planOutput(t *testing.T, args ...string) string {
+	t.Helper()
+	config, err := ParsePlanArgs(args)
+	if err != nil {
+		t.Fatalf("ParsePlanArgs() error = %v", err)
+	}
+	provider, err := calculator.LookupProvider(config.Country)
+	if err != nil {
+		t.Fatalf("LookupProvider() error = %v", err)
+	}
+	calendar := calculator.NewCalendar(provider)
+	calendar.Region = config.Region
+	plan, err := NewVacationPlan(config, calendar.PlanVacation(config.Year, config.Days))
+	if err != nil {
+		t.Fatalf("NewVacationPlan() error = %v", err)
+	}
+	return FormatPlan(plan, config)
+}
+
+func TestFormatPlan(t *testing.T) {
+	useLanguage(t, i18n.English)
+	expected := `Vacation plan 2025 (DE-BY): 3 vacation days
+
+2025-05-01 Thursday  – 2025-05-04 Sunday     4 days off for 1 vacation day
+2025-05-29 Thursday  – 2025-06-01 Sunday     4 days off for 1 vacation day
+2025-06-19 Thursday  – 2025-06-22 Sunday     4 days off for 1 vacation day
+
+Month       Working days  Vacation days  Billable days          Amount
+January               21              0             21   21,000.00 EUR
+February              20              0             20   20,000.00 EUR
+March                 21              0             21   21,000.00 EUR
+April                 20              0             20   20,000.00 EUR
+May                   20              2             18   18,000.00 EUR
+June                  19              1             18   18,000.00 EUR
+July                  23              0             23   23,000.00 EUR
+August                21              0             21   21,000.00 EUR
+September             22              0             22   22,000.00 EUR
+October               22              0             22   22,000.00 EUR
+November              20              0             20   20,000.00 EUR
+December              21              0             21   21,000.00 EUR
+Total                250              3            247  247,000.00 EUR
+
+Vacation dates: 2025-05-02,2025-05-30,2025-06-20`
+
+	result := planOutput(t, "2025", "--days", "3", "--country", "DE", "--region", "BY", "--rate", "1000", "--currency", "EUR")
+	if result != expected {
+		t.Errorf("FormatPlan() =\n%s\nwant\n%s", result, expected)
+	}
+}
+
+func TestFormatPlanUnused(t *testing.T) {
+	useLanguage(t, i18n.English)
+	result := planOutput(t, "2024", "--days", "200")
+	if !strings.Contains(result, "Vacation plan 2024 (CZ): 114 vacation days") ||
+		!strings.Contains(result, "86 vacation days left over: no break fits them") {
+		t.Errorf("FormatPlan() = %q; want 114 days planned and 86 left over", result)
+	}
+	if strings.Contains(result, "Amount") {
+		t.Errorf("FormatPlan() = %q; want no amount without a rate", result)
+	}
+}
+
+func TestFormatPlanCzech(t *testing.T) {
+	result := planOutput(t, "2025", "--days", "25", "--lang", "cs")
+	for _, want := range []string{
+		"Plán dovolené 2025 (CZ): 25 dní dovolené",
+		"2025-05-08 čtvrtek   – 2025-05-11 neděle     4 dny volna za 1 den dovolené",
+		"Celkem               252        25                 227",
+		"Dny dovolené: 2025-01-02..2025-01-03,2025-02-24..2025-02-28,",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("FormatPlan() = %q; want it to contain %q", result, want)
+		}
+	}
+}
+
+func TestFormatExcludedHolidays(t *testing.T) {
+	useLanguage(t, i18n.English)
+	calculate := func(country string) []workdays.Day {
+		result, err := workdays.Calculate(context.Background(), workdays.Month(2024, time.May), workdays.Options{Country: country, ExcludeHolidays: true})
+		if err != nil {
+			t.Fatalf("Calculate() error = %v", err)
+		}
+		return result.Days
+	}
+
+	expected := `  2024-05-01 Wednesday  Labour Day (CZ), Labour Day (DE-BY)
+  2024-05-08 Wednesday  Liberation Day (CZ)
+  2024-05-09 Thursday   Ascension Day (DE-BY)
+  2024-05-20 Monday     Whit Monday (DE-BY)
+  2024-05-30 Thursday   Corpus Christi (DE-BY)`
+	if result := FormatExcludedHolidays(calculate("CZ+DE-BY"), &Config{Verbose: true}); result != expected {
+		t.Errorf("FormatExcludedHolidays() =\n%s\nwant\n%s", result, expected)
+	}
+	if result := FormatExcludedHolidays(calculate("CZ+DE-BY"), &Config{}); result != "" {
+		t.Errorf("FormatExcludedHolidays() = %q without --verbose; want nothing", result)
+	}
+	if result := FormatExcludedHolidays(calculate("CZ"), &Config{Verbose: true}); result != "" {
+		t.Errorf("FormatExcludedHolidays() = %q for a single country; want nothing", result)
+	}
+}
+
+func TestHolidaysCombined(t *testing.T) {
+	useLanguage(t, i18n.English)
+	config, err := ParseHolidaysArgs([]string{"2024", "--country", "CZ+DE-BY", "--intersect", "--output", "json"})
+	if err != nil {
+		t.Fatalf("ParseHolidaysArgs() error = %v", err)
+	}
+	list, err := Holidays(config)
+	if err != nil {
+		t.Fatalf("Holidays() error = %v", err)
+	}
+	if len(list) != 10 {
+		t.Errorf("Holidays() = %d holidays; want the 5 days shared by CZ and DE-BY, twice", len(list))
+	}
+	result, err := FormatHolidays(list, config)
+	if err != nil || !strings.Contains(result, `"country": "DE-BY"`) {
+		t.Errorf("FormatHolidays() = %q, %v; want the holidays attributed to their country", result, err)
+	}
+}
+
+func TestHolidaysCombinedCSV(t *testing.T) {
+	config, err := ParseHolidaysArgs([]string{"2024", "--country", "CZ+DE-BY", "--output", "csv"})
+	if err != nil {
+		t.Fatalf("ParseHolidaysArgs() error = %v", err)
+	}
+	list, err := Holidays(config)
+	if err != nil {
+		t.Fatalf("Holidays() error = %v", err)
+	}
+	result, err := FormatHolidays(list, config)
+	want := "2024-05-01,Wednesday,Tag der Arbeit,Labour Day,true,labour-day,de,public,true,Feiertagsgesetze der Länder,DE-BY\n"
+	if err != nil || !strings.Contains(result, ",CZ\n") || !strings.Contains(result, want) {
+		t.Errorf("FormatHolidays() = %q, %v; want a country column", result, err)
+	}
+}
+
+func TestHolidaysOnSpecialWorkingDay(t *testing.T) {
+	config, err := ParseHolidaysArgs([]string{"2024", "--country", "CN", "--output", "json"})
+	if err != nil {
+		t.Fatalf("ParseHolidaysArgs() error = %v", err)
+	}
+	// A holiday on Sunday February 18, worked for the Spring Festival.
+	list := []holidays.Holiday{{ID: "company-day", Name: "Company Day", Date: time.Date(2024, 2, 18, 0, 0, 0, 0, time.UTC)}}
+	result, err := FormatHolidays(list, config)
+	if err != nil || !strings.Contains(result, `"workday": true`) {
+		t.Errorf("FormatHolidays() = %q, %v; want the holiday on a workday", result, err)
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/clock"
+	"time"
+)
+
+// DueConfig holds the arguments of the "billme due" command.
+type DueConfig struct {
+	Issued   time.Time
+	Net      int
+	Business bool
+	Country  string
+	Region   string
+	Verbose  bool
+	Help     bool
+}
+
+// ParseDueArgs parses "billme due [--issued date] [--net days] [--business]".
+func ParseDueArgs(args []string) (*DueConfig, error) {
+	config := &DueConfig{}
+
+	fs := flag.NewFlagSet("due", flag.ContinueOnError)
+	fs.SetOutput(nopWriter{})
+	issued := fs.String("issued", "", "issue date (YYYY-MM-DD, default today)")
+	fs.IntVar(&config.Net, "net", 14, "payment terms in days")
+	fs.BoolVar(&config.Business, "business", false, "count working days instead of calendar days")
+	fs.StringVar(&config.Country, "country", "CZ", "country whose holidays are skipped")
+	fs.StringVar(&config.Region, "region", "", "region of the country with its own holidays")
+	today := fs.String("today", "", "pretend today is this date (YYYY-MM-DD)")
+	lang := fs.String("lang", "", "output language: en or cs (default from LANG)")
+	fs.BoolVar(&config.Verbose, "v", false, "verbose output")
+	fs.BoolVar(&config.Verbose, "verbose", false, "verbose output")
+	fs.BoolVar(&config.Help, "h", false, "show help")
+	fs.BoolVar(&config.Help, "help", false, "show help")
+
+	detectLanguage()
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := setLanguage(*lang); err != nil {
+		return nil, err
+	}
+	if config.Help {
+		return config, nil
+	}
+	if len(positional) > 0 {
+		return nil, tr.Errorf("too many arguments")
+	}
+
+	if config.Net < 0 {
+		return nil, tr.Errorf("invalid payment terms: %d", config.Net)
+	}
+
+	c, err := resolveClock(*today)
+	if err != nil {
+		return nil, err
+	}
+	config.Issued = clock.Today(c)
+	if *issued != "" {
+		config.Issued, err = time.Parse("2006-01-02", *issued)
+		if err != nil {
+			return nil, tr.Errorf("invalid issue date: %s", *issued)
+		}
+	}
+
+	return config, nil
+}
+
+const dueHelp = `Usage: billme due [options]
+
+Compute an invoice due date. Calendar terms roll forward when the
+due date lands on a weekend or holiday.
+
+Examples:
+  billme due --issued 2024-07-31 --net 14             # 14 calendar days
+  billme due --issued 2024-07-31 --net 10 --business  # 10 working days
+
+Options:
+  --issued <YYYY-MM-DD>     Issue date (default today)
+  --net <days>              Payment terms in days (default 14)
+  --business                Count working days instead of calendar days
+  --country <code>          Country whose holidays are skipped (default CZ)
+                            or several joined by +, e.g. CZ+DE-BY
+  --region <code>           Region of the country with its own holidays
+  --today <YYYY-MM-DD>      Date used as today (or set BILLME_TODAY)
+  --lang <en|cs>            Output language (default from LANG)
+  -v, --verbose             Explain the computation
+`
+
+func ShowDueHelp() {
+	fmt.Print(tr.Text(dueHelp))
+}
+
+// Calendar returns the working-day calendar of the country and region.
+func (c *DueConfig) Calendar() (*calculator.Calendar, error) {
+	return dueCalendar(c.Country, c.Region)
+}
+
+// dueCalendar returns the calendar due dates roll forward on: the days off
+// of country and region, the same for billme due and ISDOC invoices.
+func dueCalendar(country, region string) (*calculator.Calendar, error) {
+	provider, err := calculator.ResolveProvider(country, region, false, 0)
+	if err != nil {
+		return nil, err
+	}
+	calendar := calculator.NewCalendar(provider)
+	calendar.Region = region
+	return calendar, nil
+}
+
+// FormatDueDate renders the due date, as a bare ISO date unless verbose.
+func FormatDueDate(due time.Time, config *DueConfig) string {
+	if !config.Verbose {
+		return due.Format("2006-01-02")
+	}
+
+	terms := tr.Plural("due.calendar", config.Net, config.Net)
+	if config.Business {
+		terms = tr.Plural("due.business", config.Net, config.Net)
+	}
+	return tr.Sprintf("Issued %s + %s: due %s %s 📅",
+		config.Issued.Format("2006-01-02"), terms, tr.Weekday(due.Weekday()), due.Format("2006-01-02"))
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"errors"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/clock"
+	"github.com/honzahovorka/billme/internal/holidays"
+	"strings"
+)
+
+// Exit codes of billme.
+const (
+	ExitError           = 1 // any other failure
+	ExitUsage           = 2 // invalid arguments or flags
+	ExitInvalidMonth    = 3 // a month outside 1-12 or not recognized
+	ExitYearOutOfRange  = 4 // a year the holiday calculation does not support
+	ExitUnknownCountry  = 5 // no holiday data for the country or region
+	ExitInvalidVacation = 6 // negative vacation days
+	ExitWorkdayFailure  = 7 // any other failure of "billme is-workday"
+)
+
+// ExitNotWorkday is the exit code of "billme is-workday" for a day off.
+// That command reports its failures with ExitUsage, ExitWorkdayFailure or a
+// more specific code, never ExitError, so scripts can tell a day off from a
+// mistake.
+const ExitNotWorkday = 1
+
+// ErrNotWorkday is returned by the is-workday command for a day off. It is
+// not printed as an error, only turned into ExitNotWorkday.
+var ErrNotWorkday = errors.New("not a working day")
+
+// ExitCode returns the exit code for err: the one of the calculator error
+// it wraps, ExitUsage for an invalid command line, holiday type or today,
+// ExitWorkdayFailure for another failure of is-workday, or fallback.
+func ExitCode(err error, fallback int) int {
+	var (
+		month    *calculator.MonthError
+		year     *calculator.YearError
+		country  *calculator.CountryError
+		region   *calculator.RegionError
+		vacation *calculator.VacationError
+		types    *holidays.HolidayTypeError
+		date     *clock.DateError
+		usage    *usageError
+		workday  *workdayError
+	)
+	switch {
+	case errors.As(err, &month):
+		return ExitInvalidMonth
+	case errors.As(err, &year):
+		return ExitYearOutOfRange
+	case errors.As(err, &country), errors.As(err, &region):
+		return ExitUnknownCountry
+	case errors.As(err, &vacation):
+		return ExitInvalidVacation
+	case errors.As(err, &types), errors.As(err, &date), errors.As(err, &usage):
+		return ExitUsage
+	case errors.As(err, &workday):
+		return ExitWorkdayFailure
+	}
+	return fallback
+}
+
+// usageError marks an error in the command line of a subcommand whose
+// other failures exit with ExitError.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// workdayError marks a failure of is-workday, which must not exit with
+// ExitError as that is ExitNotWorkday.
+type workdayError struct {
+	err error
+}
+
+func (e *workdayError) Error() string { return e.err.Error() }
+func (e *workdayError) Unwrap() error { return e.err }
+
+// localizedError is a translated message for an error that stays available
+// to errors.As.
+type localizedError struct {
+	message string
+	err     error
+}
+
+func (e *localizedError) Error() string { return e.message }
+func (e *localizedError) Unwrap() error { return e.err }
+
+// Localize translates the typed errors of the calculator, of holiday type
+// lists and of dates for today into the output language; other errors are
+// returned as they are.
+func Localize(err error) error {
+	var (
+		month    *calculator.MonthError
+		year     *calculator.YearError
+		period   *calculator.PeriodError
+		country  *calculator.CountryError
+		region   *calculator.RegionError
+		vacation *calculator.VacationError
+		excess   *calculator.ExcessVacationError
+		types    *holidays.HolidayTypeError
+		date     *clock.DateError
+		message  string
+	)
+	switch {
+	case errors.As(err, &month):
+		message = tr.Sprintf("invalid month: %d (months are 1-12)", month.Month)
+	case errors.As(err, &year):
+		message = tr.Sprintf("year out of range: %d (supported %d-%d)", year.Year, calculator.MinYear, calculator.MaxYear)
+	case errors.As(err, &period):
+		message = tr.Sprintf("period ends before it starts: %s..%s", period.Start.Format("2006-01-02"), period.End.Format("2006-01-02"))
+	case errors.As(err, &country):
+		message = tr.Sprintf("unknown country: %s", country.Country)
+	case errors.As(err, &region) && len(region.Regions) == 0:
+		message = tr.Sprintf("%s has no regional holidays", region.Country)
+	case errors.As(err, &region):
+		message = tr.Sprintf("unknown region of %s: %s (use %s)", region.Country, region.Region, strings.Join(region.Regions, ", "))
+	case errors.As(err, &vacation):
+		message = tr.Sprintf("invalid vacation days: %d (must not be negative)", vacation.Days)
+	case errors.As(err, &excess):
+		message = tr.Sprintf("vacation days (%v) exceed the working days (%v); billing 0", excess.VacationDays, excess.WorkingDays)
+	case errors.As(err, &types):
+		message = tr.Sprintf("invalid holiday type: %s (use %s or all)", types.Type, strings.Join(types.Types, ", "))
+	case errors.As(err, &date):
+		message = tr.Sprintf("invalid today date: %s", date.Value)
+	default:
+		return err
+	}
+	return &localizedError{message, err}
+}
+
+// monthError reports an invalid month argument in the output language,
+// exiting with ExitInvalidMonth.
+func monthError(month int, format string, args ...any) error {
+	return &localizedError{tr.Sprintf(format, args...), &calculator.MonthError{Month: month}}
+}
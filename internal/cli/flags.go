@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"flag"
+	"regexp"
+	"strings"
+)
+
+var negativeNumber = regexp.MustCompile(`^-\d+$`)
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments, which the flag package alone does not allow, e.g.
+// "billme number void 20240007 --reason typo". Negative numbers such as
+// "-5" are positional arguments, not flags, and everything after "--" is
+// positional.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for len(args) > 0 {
+		arg := args[0]
+		switch {
+		case arg == "--":
+			return append(positional, args[1:]...), nil
+		case arg == "-" || !strings.HasPrefix(arg, "-") || negativeNumber.MatchString(arg):
+			positional = append(positional, arg)
+			args = args[1:]
+			continue
+		}
+
+		// Hand the flag set exactly one flag and, if it takes one, its value.
+		n := 1
+		if !strings.Contains(arg, "=") && !isBoolFlag(fs, arg) && len(args) > 1 {
+			n = 2
+		}
+		if err := fs.Parse(args[:n]); err != nil {
+			return nil, err
+		}
+		args = args[n:]
+	}
+	return positional, nil
+}
+
+func isBoolFlag(fs *flag.FlagSet, arg string) bool {
+	f := fs.Lookup(strings.TrimLeft(arg, "-"))
+	if f == nil {
+		return true // let Parse report the unknown flag
+	}
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// nopWriter silences the flag package's own error output; errors are
+// reported by main together with the usage line.
+type nopWriter struct{}
+
+func (nopWriter) Write(p []byte) (int, error) { return len(p), nil }
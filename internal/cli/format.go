@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/i18n"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Report is the data available to --format templates, e.g.
+//
+//	billme --format '{{.Period}}: {{.Days}}d ({{money .Amount .Currency}})'
+type Report struct {
+	Month           int       // first billed month, 1-12
+	Year            int       // year of the first billed month
+	EndMonth        int       // last billed month; equal to Month unless a range was given
+	EndYear         int       // year of the last billed month
+	Period          string    // the billed months in the output language, e.g. "July 2024"
+	Days            int       // billable days: working days less vacation
+	WorkingDays     int       // working days before vacation is subtracted
+	VacationDays    int       // vacation days subtracted
+	ExcludeHolidays bool      // whether public holidays were left out
+	Elapsed         int       // billable days before today; zero for a range
+	Remaining       int       // billable days from today to the end of the month; zero for a range
+	Rate            float64   // daily rate
+	Amount          float64   // Days × Rate
+	Currency        string    // invoice currency
+	Client          string    // --client, if given
+	Today           time.Time // the date used as today
+	Lang            string    // output language, "en" or "cs"
+}
+
+// NewReport collects the computed values for a --format template.
+func NewReport(config *Config, workingDays, days, elapsed, remaining int) Report {
+	start, end := config.period()
+	return Report{
+		Month:           start.month,
+		Year:            start.year,
+		EndMonth:        end.month,
+		EndYear:         end.year,
+		Period:          periodName(start, end, i18n.Nominative),
+		Days:            days,
+		WorkingDays:     workingDays,
+		VacationDays:    workingDays - days,
+		ExcludeHolidays: config.ExcludeHolidays,
+		Elapsed:         elapsed,
+		Remaining:       remaining,
+		Rate:            config.Rate,
+		Amount:          PaymentAmount(days, config),
+		Currency:        config.Currency,
+		Client:          config.Client,
+		Today:           config.Today,
+		Lang:            string(tr.Lang),
+	}
+}
+
+// templateFuncs are the helpers available in --format templates.
+var templateFuncs = template.FuncMap{
+	// pad aligns value in a column of width characters: to the right for
+	// a positive width, to the left for a negative one.
+	"pad": func(width int, value any) string {
+		return fmt.Sprintf("%*v", width, value)
+	},
+	// money formats an amount with two decimals and thousands separators in
+	// the output language, followed by the currency if given.
+	"money": func(amount float64, currency ...string) string {
+		return formatMoney(amount, strings.Join(currency, " "))
+	},
+	// monthName returns the name of a month number in the output language.
+	"monthName": func(month int) (string, error) {
+		if month < 1 || month > 12 {
+			return "", fmt.Errorf("invalid month: %d", month)
+		}
+		return tr.Month(time.Month(month), i18n.Nominative), nil
+	},
+}
+
+// parseFormat compiles the --format value: the name of a template from the
+// config file, or else template text itself. A dry run against an empty
+// report catches unknown fields before anything is computed.
+func parseFormat(value string, named map[string]string) (*template.Template, error) {
+	text, ok := named[value]
+	if !ok {
+		text = value
+	}
+
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, formatError(err)
+	}
+	if err := tmpl.Execute(&strings.Builder{}, Report{Month: 1, EndMonth: 1}); err != nil {
+		return nil, formatError(err)
+	}
+	return tmpl, nil
+}
+
+// FormatReport renders report with the --format template.
+func FormatReport(tmpl *template.Template, report Report) (string, error) {
+	var out strings.Builder
+	if err := tmpl.Execute(&out, report); err != nil {
+		return "", formatError(err)
+	}
+	return out.String(), nil
+}
+
+var (
+	unknownField    = regexp.MustCompile(`can't evaluate field (\w+)`)
+	unknownFunction = regexp.MustCompile(`function "(\w+)" not defined`)
+	templateContext = regexp.MustCompile(`^template: format:(\d+)(:\d+)?: (executing "format" at <[^>]*>: )?`)
+)
+
+// formatError turns a text/template error into a message that names the
+// problem and what is available instead.
+func formatError(err error) error {
+	message := err.Error()
+
+	if m := unknownField.FindStringSubmatch(message); m != nil {
+		fields := reportFields()
+		hint := strings.Join(fields, ", ")
+		if suggestion := closest(m[1], fields); suggestion != "" {
+			return tr.Errorf("invalid format: unknown field .%s (did you mean %s?)", m[1], suggestion)
+		}
+		return tr.Errorf("invalid format: unknown field .%s (available: %s)", m[1], hint)
+	}
+	if m := unknownFunction.FindStringSubmatch(message); m != nil {
+		return tr.Errorf("invalid format: unknown function %s (available: %s)", m[1], strings.Join(templateFuncNames(), ", "))
+	}
+
+	if m := templateContext.FindStringSubmatch(message); m != nil {
+		return tr.Errorf("invalid format at line %s: %s", m[1], message[len(m[0]):])
+	}
+	return tr.Errorf("invalid format: %v", err)
+}
+
+func reportFields() []string {
+	t := reflect.TypeOf(Report{})
+	fields := make([]string, t.NumField())
+	for i := range fields {
+		fields[i] = "." + t.Field(i).Name
+	}
+	return fields
+}
+
+func templateFuncNames() []string {
+	var names []string
+	for name := range templateFuncs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// closest returns the field whose name is nearest to name, ignoring case,
+// or "" when none is a plausible typo.
+func closest(name string, fields []string) string {
+	best, bestDistance := "", 3
+	for _, field := range fields {
+		d := editDistance(strings.ToLower(name), strings.ToLower(field[1:]))
+		if d < bestDistance {
+			best, bestDistance = field, d
+		}
+	}
+	return best
+}
+
+// formatMoney renders amount with two decimals, grouping thousands the way
+// the output language does: "138,000.00" or "138 000,00".
+func formatMoney(amount float64, currency string) string {
+	thousands, decimal := ",", "."
+	if tr.Lang == i18n.Czech {
+		thousands, decimal = " ", ","
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	cents := int64(math.Round(amount * 100))
+	whole := fmt.Sprint(cents / 100)
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(thousands)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	result := fmt.Sprintf("%s%s%s%02d", sign, grouped.String(), decimal, cents%100)
+	if currency != "" {
+		result += " " + currency
+	}
+	return result
+}
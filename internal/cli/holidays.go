@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/holidays"
+	"github.com/honzahovorka/billme/internal/ical"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Output formats of the holidays command besides the default table.
+const (
+	OutputJSON = "json"
+	OutputCSV  = "csv"
+	OutputICS  = "ics"
+)
+
+// HolidaysConfig holds the arguments of "billme holidays [year]".
+type HolidaysConfig struct {
+	CalendarConfig
+	Year   int
+	Output string
+}
+
+// ParseHolidaysArgs parses "billme holidays [year] [options]".
+func ParseHolidaysArgs(args []string) (*HolidaysConfig, error) {
+	config := &HolidaysConfig{}
+
+	fs := flag.NewFlagSet("holidays", flag.ContinueOnError)
+	fs.SetOutput(nopWriter{})
+	fs.StringVar(&config.today, "today", "", "pretend today is this date (YYYY-MM-DD)")
+	fs.StringVar(&config.lang, "lang", "", "output language: en or cs (default from LANG)")
+	fs.StringVar(&config.Country, "country", "CZ", "country whose holidays are listed")
+	fs.StringVar(&config.Region, "region", "", "region of the country with its own holidays")
+	fs.BoolVar(&config.Intersect, "intersect", false, "list only the holidays of all the countries")
+	fs.StringVar(&config.Output, "output", "", "output format: json, csv or ics")
+	fs.BoolVar(&config.Help, "h", false, "show help")
+	fs.BoolVar(&config.Help, "help", false, "show help")
+	workWeek := fs.String("work-week", "mon-fri", "working days of the week, e.g. mon-thu")
+
+	detectLanguage()
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := setLanguage(config.lang); err != nil {
+		return nil, err
+	}
+	if config.Help {
+		return config, nil
+	}
+	if err := config.finish(*workWeek); err != nil {
+		return nil, err
+	}
+
+	switch config.Output {
+	case "", OutputJSON, OutputCSV, OutputICS:
+	default:
+		return nil, tr.Errorf("unknown output format: %s (use json, csv or ics)", config.Output)
+	}
+
+	switch len(positional) {
+	case 0:
+		config.Year = config.Today.Year()
+	case 1:
+		config.Year, err = strconv.Atoi(positional[0])
+		if err != nil {
+			return nil, tr.Errorf("invalid year: %s", positional[0])
+		}
+	default:
+		return nil, tr.Errorf("too many arguments")
+	}
+	if config.Year < calculator.MinYear || config.Year > calculator.MaxYear {
+		return nil, Localize(&calculator.YearError{Year: config.Year})
+	}
+
+	return config, nil
+}
+
+// Holidays returns the holidays of the configured year, country and region
+// in date order.
+func Holidays(config *HolidaysConfig) ([]holidays.Holiday, error) {
+	provider, err := calculator.ResolveProvider(config.Country, config.Region, config.Intersect, config.HolidayTypes)
+	if err != nil {
+		return nil, Localize(err)
+	}
+
+	list := holidays.ForRegion(provider, config.Year, config.Region)
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Date.Before(list[j].Date) })
+	return list, nil
+}
+
+// FormatHolidays renders the holidays in the configured output format.
+func FormatHolidays(list []holidays.Holiday, config *HolidaysConfig) (string, error) {
+	calendar, err := config.Calendar()
+	if err != nil {
+		return "", Localize(err)
+	}
+	switch config.Output {
+	case OutputJSON:
+		return holidaysJSON(list, calendar)
+	case OutputCSV:
+		return holidaysCSV(list, calendar)
+	case OutputICS:
+		return holidaysICS(list, config)
+	}
+	return holidaysTable(list, config, calendar), nil
+}
+
+// onWorkday reports whether holiday falls on a day that would be worked
+// but for it: a day of the work week or a special working day, as
+// is-workday sees it.
+func onWorkday(holiday holidays.Holiday, calendar *calculator.Calendar) bool {
+	_, working := calendar.WorkingDay(holiday.Date)
+	return working || calendar.WorkWeek.Has(holiday.Date.Weekday())
+}
+
+// holidayName returns the name of holiday in the output language.
+func holidayName(holiday holidays.Holiday) string {
+	if holiday.Country != "" {
+		return fmt.Sprintf("%s (%s)", holiday.NameIn(string(tr.Lang)), holiday.Country)
+	}
+	return holiday.NameIn(string(tr.Lang))
+}
+
+// otherHolidayName returns the name of holiday that holidayName does not
+// show: the English one next to a local name and the reverse.
+func otherHolidayName(holiday holidays.Holiday) string {
+	if holiday.NameIn(string(tr.Lang)) == holiday.Name {
+		return holiday.EnglishName
+	}
+	return holiday.Name
+}
+
+func holidaysTable(list []holidays.Holiday, config *HolidaysConfig, calendar *calculator.Calendar) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", tr.Sprintf("Public holidays %d (%s)", config.Year, strings.ToUpper(config.Country)))
+
+	nameWidth, otherWidth, typeWidth := 0, 0, 0
+	for _, holiday := range list {
+		nameWidth = max(nameWidth, len([]rune(holidayName(holiday))))
+		otherWidth = max(otherWidth, len([]rune(otherHolidayName(holiday))))
+		typeWidth = max(typeWidth, len([]rune(tr.Text(holiday.Type.String()))))
+	}
+
+	workdays := 0
+	for _, holiday := range list {
+		kind := tr.Text("weekend")
+		if onWorkday(holiday, calendar) {
+			kind = tr.Text("workday")
+			workdays++
+		}
+		shops := ""
+		if holiday.ShopsClosed {
+			shops = "  " + tr.Text("shops closed")
+		}
+		line := fmt.Sprintf("%s  %-10s  %-*s  %-*s  %-*s  %-12s%s", holiday.Date.Format("2006-01-02"), tr.Weekday(holiday.Date.Weekday()),
+			nameWidth, holidayName(holiday), otherWidth, otherHolidayName(holiday), typeWidth, tr.Text(holiday.Type.String()), kind, shops)
+		fmt.Fprintf(&b, "%s\n", strings.TrimRight(line, " "))
+	}
+
+	fmt.Fprintf(&b, "\n%s", tr.Plural("holidays.workdays", workdays, workdays, len(list)))
+	return b.String()
+}
+
+// holidayRecord is a holiday in the JSON output.
+type holidayRecord struct {
+	ID          string `json:"id"`
+	Date        string `json:"date"`
+	Weekday     string `json:"weekday"`
+	Name        string `json:"name"`
+	EnglishName string `json:"english_name"`
+	Language    string `json:"language"`
+	Type        string `json:"type"`
+	ShopsClosed bool   `json:"shops_closed"`
+	Source      string `json:"source"`
+	Country     string `json:"country,omitempty"`
+	Workday     bool   `json:"workday"`
+}
+
+func holidaysJSON(list []holidays.Holiday, calendar *calculator.Calendar) (string, error) {
+	records := []holidayRecord{}
+	for _, holiday := range list {
+		records = append(records, holidayRecord{
+			ID:          holiday.ID,
+			Date:        holiday.Date.Format("2006-01-02"),
+			Weekday:     holiday.Date.Weekday().String(),
+			Name:        holiday.Name,
+			EnglishName: holiday.EnglishName,
+			Language:    holiday.Language,
+			Type:        holiday.Type.String(),
+			ShopsClosed: holiday.ShopsClosed,
+			Source:      holiday.Source,
+			Country:     holiday.Country,
+			Workday:     onWorkday(holiday, calendar),
+		})
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func holidaysCSV(list []holidays.Holiday, calendar *calculator.Calendar) (string, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write([]string{"date", "weekday", "name", "english_name", "workday", "id", "language", "type", "shops_closed", "source", "country"})
+	for _, holiday := range list {
+		w.Write([]string{
+			holiday.Date.Format("2006-01-02"),
+			holiday.Date.Weekday().String(),
+			holiday.Name,
+			holiday.EnglishName,
+			strconv.FormatBool(onWorkday(holiday, calendar)),
+			holiday.ID,
+			holiday.Language,
+			holiday.Type.String(),
+			strconv.FormatBool(holiday.ShopsClosed),
+			holiday.Source,
+			holiday.Country,
+		})
+	}
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n"), w.Error()
+}
+
+// holidaysICS renders the holidays as all-day iCalendar events.
+func holidaysICS(list []holidays.Holiday, config *HolidaysConfig) (string, error) {
+	var b strings.Builder
+	err := ical.Write(&b, ical.Calendar{
+		Name:   tr.Sprintf("Public holidays %d (%s)", config.Year, strings.ToUpper(config.Country)),
+		Events: ical.HolidayEvents(list, config.Country, config.Region, string(tr.Lang)),
+	}, config.Today)
+	return b.String(), err
+}
+
+const holidaysHelp = `Usage: billme holidays [year] [options]
+
+List the public holidays of a year with their local and English names,
+the one in the output language first, their type, whether shops close and
+whether each falls on a working day. JSON and CSV add an ID and the law
+that establishes each holiday.
+
+Examples:
+  billme holidays                 # this year's holidays
+  billme holidays 2025 --output csv
+  billme holidays --output ics > holidays.ics
+  billme holidays --country DE --region BY
+
+Options:
+  --country <code>          Country of the holidays (default CZ)
+                            or several joined by +, e.g. CZ+DE-BY
+  --region <code>           Region with its own holidays
+  --intersect               List only the holidays shared by all countries
+  --output <json|csv|ics>   Machine-readable output instead of the table
+  --work-week <days>        Working days of the week (default mon-fri)
+  --today <YYYY-MM-DD>      Date used as today (or set BILLME_TODAY)
+  --lang <en|cs>            Output language (default from LANG)
+`
+
+func ShowHolidaysHelp() {
+	fmt.Print(tr.Text(holidaysHelp))
+}
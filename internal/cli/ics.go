@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/holidays"
+	"github.com/honzahovorka/billme/internal/ical"
+	"github.com/honzahovorka/billme/internal/settings"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ICSConfig holds the arguments of "billme ics [period]".
+type ICSConfig struct {
+	CalendarConfig
+	Start      time.Time
+	End        time.Time
+	Vacation   []time.Time
+	NoHolidays bool
+	NoVacation bool
+}
+
+// ParseICSArgs parses "billme ics [period] [options]". The period is a year,
+// or months in the syntax of the billable days calculation; it defaults to
+// the current year.
+func ParseICSArgs(args []string) (*ICSConfig, error) {
+	config := &ICSConfig{}
+
+	fs := flag.NewFlagSet("ics", flag.ContinueOnError)
+	fs.SetOutput(nopWriter{})
+	fs.StringVar(&config.today, "today", "", "pretend today is this date (YYYY-MM-DD)")
+	fs.StringVar(&config.lang, "lang", "", "output language: en or cs (default from LANG)")
+	fs.StringVar(&config.Country, "country", "CZ", "country whose holidays are exported")
+	fs.StringVar(&config.Region, "region", "", "region of the country with its own holidays")
+	fs.BoolVar(&config.Intersect, "intersect", false, "export only the holidays of all the countries")
+	fs.BoolVar(&config.NoHolidays, "no-holidays", false, "export only the vacation")
+	fs.BoolVar(&config.NoVacation, "no-vacation", false, "export only the holidays")
+	fs.BoolVar(&config.Help, "h", false, "show help")
+	fs.BoolVar(&config.Help, "help", false, "show help")
+	vacation := fs.String("vacation", "", "vacation dates instead of the ones in the config file")
+	configPath := fs.String("config", settings.DefaultPath(), "path to the config file")
+	workWeek := fs.String("work-week", "mon-fri", "working days of the week, e.g. mon-thu")
+
+	detectLanguage()
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := setLanguage(config.lang); err != nil {
+		return nil, err
+	}
+	if config.Help {
+		return config, nil
+	}
+	if err := config.finish(*workWeek); err != nil {
+		return nil, err
+	}
+
+	if config.Start, config.End, err = icsPeriod(positional, config.Today); err != nil {
+		return nil, err
+	}
+
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	dates, err := loadVacation(*vacation, *configPath, explicit["config"])
+	if err != nil {
+		return nil, err
+	}
+	for _, date := range dates {
+		if !date.Before(config.Start) && !date.After(config.End) {
+			config.Vacation = append(config.Vacation, date)
+		}
+	}
+
+	return config, nil
+}
+
+// icsPeriod returns the first and last day of the exported period.
+func icsPeriod(args []string, now time.Time) (time.Time, time.Time, error) {
+	year := now.Year()
+	if len(args) == 1 && len(args[0]) == 4 && digits.MatchString(args[0]) {
+		year, _ = strconv.Atoi(args[0])
+		args = nil
+	}
+	if len(args) == 0 {
+		if year < calculator.MinYear || year > calculator.MaxYear {
+			return time.Time{}, time.Time{}, Localize(&calculator.YearError{Year: year})
+		}
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	start, end, err := parsePeriod(args, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return time.Date(start.year, time.Month(start.month), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(end.year, time.Month(end.month)+1, 0, 0, 0, 0, 0, time.UTC), nil
+}
+
+// ICS renders the holidays and the vacation of the configured period as an
+// iCalendar file. Vacation is exported only for days of the work week.
+func ICS(config *ICSConfig) (string, error) {
+	country := strings.ToUpper(config.Country)
+	calendar := ical.Calendar{Name: tr.Sprintf("Holidays and vacation (%s)", country)}
+
+	if !config.NoHolidays {
+		provider, err := calculator.ResolveProvider(config.Country, config.Region, config.Intersect, config.HolidayTypes)
+		if err != nil {
+			return "", Localize(err)
+		}
+
+		var list []holidays.Holiday
+		for year := config.Start.Year(); year <= config.End.Year(); year++ {
+			for _, holiday := range holidays.ForRegion(provider, year, config.Region) {
+				if !holiday.Date.Before(config.Start) && !holiday.Date.After(config.End) {
+					list = append(list, holiday)
+				}
+			}
+		}
+		calendar.Events = append(calendar.Events, ical.HolidayEvents(list, config.Country, config.Region, string(tr.Lang))...)
+	}
+
+	if !config.NoVacation {
+		var absent []time.Time
+		for _, date := range config.Vacation {
+			if config.WorkWeek.Has(date.Weekday()) {
+				absent = append(absent, date)
+			}
+		}
+		calendar.Events = append(calendar.Events, ical.AbsenceEvents(absent, tr.Text("Vacation"))...)
+	}
+
+	var b strings.Builder
+	err := ical.Write(&b, calendar, config.Today)
+	return b.String(), err
+}
+
+const icsHelp = `Usage: billme ics [period] [options]
+
+Export the public holidays and your vacation as all-day calendar events
+(iCalendar, RFC 5545) to import into any calendar application. Events
+keep the same UID across exports, so importing again updates them
+instead of adding duplicates.
+
+The period is a year, a month or a range of months, as in the billable
+days calculation; it defaults to the current year. Vacation comes from
+--vacation or the "vacation" list of the config file.
+
+Examples:
+  billme ics > calendar.ics        # this year's holidays and vacation
+  billme ics 2025 --no-vacation    # only the holidays of 2025
+  billme ics 7..9 --vacation 2024-07-08..2024-07-12
+
+Options:
+  --country <code>          Country of the holidays (default CZ)
+                            or several joined by +, e.g. CZ+DE-BY
+  --region <code>           Region with its own holidays
+  --intersect               Export only the holidays shared by all countries
+  --vacation <dates>        Vacation dates instead of the config file
+  --no-holidays             Export only the vacation
+  --no-vacation             Export only the holidays
+  --work-week <days>        Working days of the week (default mon-fri)
+  --config <file>           Config file with the recorded vacation
+  --today <YYYY-MM-DD>      Date used as today (or set BILLME_TODAY)
+  --lang <en|cs>            Output language (default from LANG)
+`
+
+func ShowICSHelp() {
+	fmt.Print(tr.Text(icsHelp))
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/honzahovorka/billme/internal/settings"
+	"github.com/honzahovorka/billme/pkg/workdays"
+	"strings"
+	"time"
+)
+
+// IsWorkdayConfig holds the arguments of "billme is-workday [date]".
+type IsWorkdayConfig struct {
+	CalendarConfig
+	Date     time.Time
+	Vacation []time.Time
+	Verbose  bool
+}
+
+// ParseIsWorkdayArgs parses "billme is-workday [date] [options]". The date
+// defaults to today. Every error exits with ExitUsage or a more specific
+// code, keeping ExitNotWorkday unambiguous.
+func ParseIsWorkdayArgs(args []string) (*IsWorkdayConfig, error) {
+	config, err := parseIsWorkdayArgs(args)
+	if err != nil {
+		return nil, &usageError{err}
+	}
+	return config, nil
+}
+
+func parseIsWorkdayArgs(args []string) (*IsWorkdayConfig, error) {
+	config := &IsWorkdayConfig{}
+
+	fs := flag.NewFlagSet("is-workday", flag.ContinueOnError)
+	fs.SetOutput(nopWriter{})
+	workWeek := config.register(fs)
+	fs.BoolVar(&config.Verbose, "v", false, "print why the day is or is not a working day")
+	fs.BoolVar(&config.Verbose, "verbose", false, "print why the day is or is not a working day")
+	vacation := fs.String("vacation", "", "vacation dates instead of the ones in the config file")
+	configPath := fs.String("config", settings.DefaultPath(), "path to the config file")
+
+	detectLanguage()
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := setLanguage(config.lang); err != nil {
+		return nil, err
+	}
+	if config.Help {
+		return config, nil
+	}
+	if err := config.finish(*workWeek); err != nil {
+		return nil, err
+	}
+
+	switch len(positional) {
+	case 0:
+		config.Date = config.Today
+	case 1:
+		config.Date, err = time.Parse("2006-01-02", positional[0])
+		if err != nil {
+			return nil, tr.Errorf("invalid date: %s", positional[0])
+		}
+	default:
+		return nil, tr.Errorf("too many arguments")
+	}
+
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	config.Vacation, err = loadVacation(*vacation, *configPath, explicit["config"])
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// IsWorkday classifies the configured date as the billable days calculation
+// would: a working day, a weekend, a public holiday or vacation.
+func IsWorkday(config *IsWorkdayConfig) (workdays.Day, error) {
+	opts := workdays.Options{
+		Country:         config.Country,
+		Region:          config.Region,
+		Intersect:       config.Intersect,
+		ExcludeHolidays: !config.IgnoreHolidays,
+		HolidayTypes:    config.HolidayTypes,
+		WorkWeek:        config.WorkWeek,
+	}
+	for _, date := range config.Vacation {
+		opts.Leave = append(opts.Leave, workdays.Leave{Date: date})
+	}
+
+	result, err := workdays.Calculate(context.Background(), workdays.Period{Start: config.Date, End: config.Date}, opts)
+	if err != nil {
+		return workdays.Day{}, &workdayError{err}
+	}
+	return result.Days[0], nil
+}
+
+// FormatWorkday explains whether day is a working day and why not.
+func FormatWorkday(day workdays.Day) string {
+	date := fmt.Sprintf("%s %s", day.Date.Format("2006-01-02"), tr.Weekday(day.Weekday))
+
+	var reason string
+	switch day.Kind {
+	case workdays.Workday:
+		if day.WorkingDay != nil {
+			return tr.Sprintf("%s is a working day: %s", date, holidayName(*day.WorkingDay))
+		}
+		return tr.Sprintf("%s is a working day", date)
+	case workdays.Weekend:
+		reason = tr.Text("weekend")
+	case workdays.Holiday:
+		names := make([]string, len(day.Holidays))
+		for i, holiday := range day.Holidays {
+			names[i] = holidayName(holiday)
+		}
+		reason = tr.Sprintf("public holiday %s", strings.Join(names, ", "))
+	default:
+		reason = tr.Text("vacation")
+	}
+	return tr.Sprintf("%s is not a working day: %s", date, reason)
+}
+
+const isWorkdayHelp = `Usage: billme is-workday [YYYY-MM-DD] [options]
+
+Check whether a date, today by default, is a working day. Exits 0 for a
+working day and 1 for a weekend, public holiday or vacation, so scripts
+and cron jobs can guard on it; errors exit 2 or higher.
+
+Examples:
+  billme is-workday && ./send-invoices.sh
+  billme is-workday 2024-07-05 -v   # prints the reason
+  0 9 * * * billme is-workday && ./daily-report.sh
+
+Options:
+  -v, --verbose             Print why the day is or is not a working day
+  --country <code>          Country whose holidays are days off (default CZ)
+                            or several joined by +, e.g. CZ+DE-BY
+  --region <code>           Region with its own holidays
+  --intersect               Only holidays in all the countries are days off
+  --holiday-types <types>   Types of holiday that are days off
+                            (default public,regional; all for every type)
+  --ignore-holidays         Treat holidays as working days
+  --vacation <dates>        Vacation dates instead of the config file
+  --work-week <days>        Working days of the week (default mon-fri)
+  --config <file>           Config file with the recorded vacation
+  --today <YYYY-MM-DD>      Date used as today (or set BILLME_TODAY)
+  --lang <en|cs>            Output language (default from LANG)
+`
+
+func ShowIsWorkdayHelp() {
+	fmt.Print(tr.Text(isWorkdayHelp))
+}
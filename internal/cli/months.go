@@ -0,0 +1,285 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/i18n"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// monthYear is a calendar month.
+type monthYear struct {
+	month int
+	year  int
+}
+
+func (m monthYear) before(other monthYear) bool {
+	return m.year < other.year || m.year == other.year && m.month < other.month
+}
+
+// monthNames lists every accepted spelling of each month, English and Czech,
+// full names first. Czech includes the genitive ("července") used in dates
+// and the common three-letter abbreviations; diacritics are optional.
+var monthNames = [12][]string{
+	{"january", "jan", "leden", "ledna", "led"},
+	{"february", "feb", "únor", "února", "úno"},
+	{"march", "mar", "březen", "března", "bře"},
+	{"april", "apr", "duben", "dubna", "dub"},
+	{"may", "květen", "května", "kvě"},
+	{"june", "jun", "červen", "června", "čer", "čvn"},
+	{"july", "jul", "červenec", "července", "čec", "čvc"},
+	{"august", "aug", "srpen", "srpna", "srp"},
+	{"september", "sep", "sept", "září", "zář"},
+	{"october", "oct", "říjen", "října", "říj"},
+	{"november", "nov", "listopad", "listopadu", "lis"},
+	{"december", "dec", "prosinec", "prosince", "pro"},
+}
+
+// relativeMonths maps keywords to an offset from the current month.
+var relativeMonths = map[string]int{
+	"last":     -1,
+	"previous": -1,
+	"prev":     -1,
+	"minulý":   -1,
+	"this":     0,
+	"current":  0,
+	"tento":    0,
+	"next":     1,
+	"příští":   1,
+}
+
+var (
+	isoMonth    = regexp.MustCompile(`^(\d{4})-(\d{1,2})$`)
+	monthOffset = regexp.MustCompile(`^[+-]\d+$`)
+	digits      = regexp.MustCompile(`^\d+$`)
+)
+
+var foldDiacritics = strings.NewReplacer(
+	"á", "a", "č", "c", "ď", "d", "é", "e", "ě", "e", "í", "i", "ň", "n",
+	"ó", "o", "ř", "r", "š", "s", "ť", "t", "ú", "u", "ů", "u", "ý", "y", "ž", "z",
+)
+
+func fold(s string) string {
+	return foldDiacritics.Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// parseMonthYear parses the optional [month] [year] positional arguments of
+// a single month, defaulting to the month and year of now.
+func parseMonthYear(args []string, now time.Time) (int, int, error) {
+	start, end, err := parsePeriod(args, now)
+	if err != nil {
+		return 0, 0, err
+	}
+	if start != end {
+		return 0, 0, tr.Errorf("a month range is not supported here: %s", args[0])
+	}
+	return start.month, start.year, nil
+}
+
+// parsePeriod parses the positional arguments naming the billed months:
+// nothing for the current month, a month with an optional year, or a range
+// "from..to" whose ends may omit the year when it is given separately.
+func parsePeriod(args []string, now time.Time) (monthYear, monthYear, error) {
+	current := monthYear{int(now.Month()), now.Year()}
+
+	switch len(args) {
+	case 0:
+		return current, current, nil
+	case 1, 2:
+	default:
+		return monthYear{}, monthYear{}, tr.Errorf("too many arguments")
+	}
+
+	year := 0
+	if len(args) == 2 {
+		var err error
+		year, err = strconv.Atoi(args[1])
+		if err != nil {
+			return monthYear{}, monthYear{}, tr.Errorf("invalid year: %s", args[1])
+		}
+	}
+
+	from, to, isRange := strings.Cut(args[0], "..")
+	start, err := parseMonth(from, year, now)
+	if err != nil {
+		return monthYear{}, monthYear{}, err
+	}
+	if !isRange {
+		return start, start, nil
+	}
+
+	end, err := parseMonth(to, year, now)
+	if err != nil {
+		return monthYear{}, monthYear{}, err
+	}
+	if end.before(start) {
+		return monthYear{}, monthYear{}, tr.Errorf("range ends before it starts: %s (use YYYY-MM..YYYY-MM to span years)", args[0])
+	}
+	return start, end, nil
+}
+
+// parseMonth parses a single month: a number, a name, "YYYY-MM", a keyword
+// such as "last" or "next", or an offset such as "-1". year, when not zero,
+// is the year given as a separate argument.
+func parseMonth(arg string, year int, now time.Time) (monthYear, error) {
+	value := fold(arg)
+
+	withYear := func(month int) monthYear {
+		if year == 0 {
+			year = now.Year()
+		}
+		return monthYear{month, year}
+	}
+	relative := func(offset int) (monthYear, error) {
+		if year != 0 {
+			return monthYear{}, tr.Errorf("%s already includes the year", arg)
+		}
+		t := time.Date(now.Year(), now.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		return monthYear{int(t.Month()), t.Year()}, nil
+	}
+
+	switch {
+	case isoMonth.MatchString(value):
+		parts := isoMonth.FindStringSubmatch(value)
+		month, _ := strconv.Atoi(parts[2])
+		if month < 1 || month > 12 {
+			return monthYear{}, monthError(month, "invalid month: %s (months are 1-12)", arg)
+		}
+		if year != 0 {
+			return monthYear{}, tr.Errorf("%s already includes the year", arg)
+		}
+		y, _ := strconv.Atoi(parts[1])
+		return monthYear{month, y}, nil
+
+	case monthOffset.MatchString(value):
+		offset, err := strconv.Atoi(value)
+		if err != nil {
+			return monthYear{}, monthError(0, "invalid month: %s", arg)
+		}
+		return relative(offset)
+
+	case digits.MatchString(value):
+		month, err := strconv.Atoi(value)
+		if err != nil || month < 1 || month > 12 {
+			return monthYear{}, monthError(month, "invalid month: %s (months are 1-12)", arg)
+		}
+		return withYear(month), nil
+	}
+
+	for keyword, offset := range relativeMonths {
+		if value == fold(keyword) {
+			return relative(offset)
+		}
+	}
+
+	month, ambiguous := lookupMonthName(value)
+	if month != 0 {
+		return withYear(month), nil
+	}
+	if len(ambiguous) > 0 {
+		return monthYear{}, monthError(0, "invalid month: %s (did you mean %s?)", arg, strings.Join(ambiguous, tr.Text(" or ")))
+	}
+
+	if suggestion := closestMonthInput(value); suggestion != "" {
+		return monthYear{}, monthError(0, "invalid month: %s (did you mean %s?)", arg, suggestion)
+	}
+	return monthYear{}, monthError(0, "invalid month: %s", arg)
+}
+
+// lookupMonthName matches a folded month name or abbreviation exactly, or
+// an unambiguous prefix of at least three letters ("sept", "listop"). For
+// a prefix of several months it returns their full names instead.
+func lookupMonthName(value string) (int, []string) {
+	var matches []int
+	for i, names := range monthNames {
+		for _, name := range names {
+			if value == fold(name) {
+				return i + 1, nil
+			}
+		}
+		for _, name := range names {
+			if len(value) >= 3 && strings.HasPrefix(fold(name), value) {
+				matches = append(matches, i)
+				break
+			}
+		}
+	}
+
+	if len(matches) == 1 {
+		return matches[0] + 1, nil
+	}
+	var ambiguous []string
+	for _, i := range matches {
+		ambiguous = append(ambiguous, monthNames[i][0])
+	}
+	return 0, ambiguous
+}
+
+// closestMonthInput suggests the month name or keyword nearest to value, or
+// "" when nothing is close enough to be a plausible typo. Ties go to the
+// earlier candidate, so full names win over abbreviations.
+func closestMonthInput(value string) string {
+	var candidates []string
+	for _, names := range monthNames {
+		candidates = append(candidates, names...)
+	}
+	keywords := make([]string, 0, len(relativeMonths))
+	for keyword := range relativeMonths {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	candidates = append(candidates, keywords...)
+
+	best, bestDistance := "", 3
+	if len([]rune(value)) <= 3 {
+		bestDistance = 2
+	}
+	for _, candidate := range candidates {
+		d := editDistance(value, fold(candidate))
+		if d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b in runes.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		cur := make([]int, len(t)+1)
+		cur[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(t)]
+}
+
+// periodName renders the billed months in grammatical case c, e.g. "July
+// 2024", "July–September 2024", "červenec–září 2024" or, in the locative,
+// "červenci 2024".
+func periodName(start, end monthYear, c i18n.Case) string {
+	first := tr.Month(time.Month(start.month), c)
+	last := tr.Month(time.Month(end.month), c)
+	switch {
+	case start == end:
+		return fmt.Sprintf("%s %d", first, start.year)
+	case start.year == end.year:
+		return fmt.Sprintf("%s–%s %d", first, last, end.year)
+	default:
+		return fmt.Sprintf("%s %d–%s %d", first, start.year, last, end.year)
+	}
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"github.com/honzahovorka/billme/internal/clock"
+	"github.com/honzahovorka/billme/internal/numbering"
+	"github.com/honzahovorka/billme/internal/settings"
+	"strings"
+	"time"
+)
+
+// NumberConfig holds the arguments of the "billme number" command.
+type NumberConfig struct {
+	Action    string // next, void, reissue or list
+	Number    string
+	Reason    string
+	Client    string
+	Date      time.Time
+	StateFile string
+	Help      bool
+	Settings  settings.Settings
+}
+
+// ParseNumberArgs parses "billme number <action> [number] [options]".
+func ParseNumberArgs(args []string) (*NumberConfig, error) {
+	config := &NumberConfig{}
+
+	fs := flag.NewFlagSet("number", flag.ContinueOnError)
+	fs.SetOutput(nopWriter{})
+	fs.StringVar(&config.Client, "client", "", "client from the config file")
+	fs.StringVar(&config.Reason, "reason", "", "reason for voiding or reissuing")
+	date := fs.String("date", "", "issue date for the series (YYYY-MM-DD)")
+	configPath := fs.String("config", settings.DefaultPath(), "path to the config file")
+	today := fs.String("today", "", "pretend today is this date (YYYY-MM-DD)")
+	lang := fs.String("lang", "", "output language: en or cs (default from LANG)")
+	fs.BoolVar(&config.Help, "h", false, "show help")
+	fs.BoolVar(&config.Help, "help", false, "show help")
+
+	detectLanguage()
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := setLanguage(*lang); err != nil {
+		return nil, err
+	}
+	if config.Help {
+		return config, nil
+	}
+
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	fileSettings, err := settings.Load(*configPath, explicit["config"])
+	if err != nil {
+		return nil, err
+	}
+	config.Settings = *fileSettings
+
+	config.StateFile = config.Settings.Numbering.StateFile
+	if config.StateFile == "" {
+		config.StateFile = numbering.DefaultPath()
+	}
+
+	c, err := resolveClock(*today)
+	if err != nil {
+		return nil, err
+	}
+	config.Date = clock.Today(c)
+	if *date != "" {
+		config.Date, err = time.Parse("2006-01-02", *date)
+		if err != nil {
+			return nil, tr.Errorf("invalid date: %s", *date)
+		}
+	}
+
+	if len(positional) == 0 {
+		return nil, tr.Errorf("missing action: next, void, reissue or list")
+	}
+	config.Action = positional[0]
+
+	switch config.Action {
+	case "next", "list":
+		if len(positional) > 1 {
+			return nil, tr.Errorf("too many arguments")
+		}
+	case "void", "reissue":
+		if len(positional) != 2 {
+			return nil, tr.Errorf("usage: billme number %s <number> --reason <text>", config.Action)
+		}
+		config.Number = positional[1]
+		if config.Reason == "" {
+			return nil, tr.Errorf("--reason is required to %s an invoice number", config.Action)
+		}
+	default:
+		return nil, tr.Errorf("unknown action: %s", config.Action)
+	}
+
+	return config, nil
+}
+
+const numberHelp = `Usage: billme number <action> [number] [options]
+
+Issue and manage continuous invoice numbers.
+
+Actions:
+  next                      Issue the next number of the series
+  void <number>             Void an issued number (stays used)
+  reissue <number>          Put a voided number back into use
+  list                      List issued numbers and the audit trail
+
+Options:
+  --client <name>           Client from the config file (own prefix/pattern)
+  --reason <text>           Reason for void/reissue (required)
+  --date <YYYY-MM-DD>       Issue date that selects the series (default today)
+  --today <YYYY-MM-DD>      Date used as today (or set BILLME_TODAY)
+  --config <file>           Config file
+  --lang <en|cs>            Output language (default from LANG)
+`
+
+func ShowNumberHelp() {
+	fmt.Print(tr.Text(numberHelp))
+}
+
+// FormatNumberEntries renders issued numbers and the audit trail.
+func FormatNumberEntries(entries []numbering.Entry, audit []numbering.Event) string {
+	if len(entries) == 0 {
+		return tr.Text("No invoice numbers issued yet.")
+	}
+
+	var lines []string
+	for _, e := range entries {
+		client := e.Client
+		if client == "" {
+			client = "-"
+		}
+		lines = append(lines, fmt.Sprintf("%-16s %-8s %-12s %s", e.Number, e.Status, client, e.IssuedAt.Format("2006-01-02 15:04")))
+	}
+
+	lines = append(lines, "", tr.Text("Audit trail:"))
+	for _, ev := range audit {
+		line := fmt.Sprintf("%s  %-8s %s", ev.Time.Format("2006-01-02 15:04"), ev.Action, ev.Number)
+		if ev.Reason != "" {
+			line += " (" + ev.Reason + ")"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/i18n"
+	"github.com/honzahovorka/billme/internal/settings"
+	"github.com/honzahovorka/billme/pkg/workdays"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PlanConfig holds the arguments of "billme plan-vacation [year]".
+type PlanConfig struct {
+	CalendarConfig
+	Year     int
+	Days     int
+	Rate     float64
+	Currency string
+}
+
+// ParsePlanArgs parses "billme plan-vacation [year] [options]". The rate and
+// currency default to those of the config file.
+func ParsePlanArgs(args []string) (*PlanConfig, error) {
+	config := &PlanConfig{}
+
+	fs := flag.NewFlagSet("plan-vacation", flag.ContinueOnError)
+	fs.SetOutput(nopWriter{})
+	workWeek := config.register(fs)
+	fs.IntVar(&config.Days, "days", 20, "vacation days to plan")
+	fs.Float64Var(&config.Rate, "rate", 0, "daily rate used to compute the amount of each month")
+	fs.StringVar(&config.Currency, "currency", "CZK", "currency of the rate (ISO 4217)")
+	configPath := fs.String("config", settings.DefaultPath(), "path to the config file")
+
+	detectLanguage()
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := setLanguage(config.lang); err != nil {
+		return nil, err
+	}
+	if config.Help {
+		return config, nil
+	}
+	if err := config.finish(*workWeek); err != nil {
+		return nil, err
+	}
+
+	switch len(positional) {
+	case 0:
+		config.Year = config.Today.Year()
+	case 1:
+		config.Year, err = strconv.Atoi(positional[0])
+		if err != nil {
+			return nil, tr.Errorf("invalid year: %s", positional[0])
+		}
+	default:
+		return nil, tr.Errorf("too many arguments")
+	}
+	if config.Year < calculator.MinYear || config.Year > calculator.MaxYear {
+		return nil, Localize(&calculator.YearError{Year: config.Year})
+	}
+	if config.Days < 0 {
+		return nil, Localize(&calculator.VacationError{Days: config.Days})
+	}
+
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	fileSettings, err := settings.Load(*configPath, explicit["config"])
+	if err != nil {
+		return nil, err
+	}
+	if !explicit["rate"] && fileSettings.Rate != 0 {
+		config.Rate = fileSettings.Rate
+	}
+	if !explicit["currency"] && fileSettings.Currency != "" {
+		config.Currency = fileSettings.Currency
+	}
+
+	return config, nil
+}
+
+// VacationPlan is a plan of the year's vacation and the billable days it
+// leaves in every month.
+type VacationPlan struct {
+	Breaks []calculator.Break
+	Months []PlanMonth
+	Unused int // vacation days for which no break fits
+}
+
+// PlanMonth is a month of a vacation plan.
+type PlanMonth struct {
+	Month       time.Month
+	WorkingDays int
+	Vacation    int
+	Billable    int
+}
+
+// NewVacationPlan counts the billable days of every month of the year with
+// the vacation of breaks taken.
+func NewVacationPlan(config *PlanConfig, breaks []calculator.Break) (*VacationPlan, error) {
+	plan := &VacationPlan{Breaks: breaks, Unused: config.Days}
+	opts := workdays.Options{
+		Country:         config.Country,
+		Region:          config.Region,
+		Intersect:       config.Intersect,
+		ExcludeHolidays: !config.IgnoreHolidays,
+		HolidayTypes:    config.HolidayTypes,
+		WorkWeek:        config.WorkWeek,
+	}
+	if config.IgnoreHolidays {
+		opts.Country = ""
+	}
+	for _, b := range breaks {
+		plan.Unused -= len(b.Vacation)
+		for _, day := range b.Vacation {
+			opts.Leave = append(opts.Leave, workdays.Leave{Date: day})
+		}
+	}
+
+	result, err := workdays.Calculate(context.Background(), workdays.Months(config.Year, time.January, config.Year, time.December), opts)
+	if err != nil {
+		return nil, Localize(err)
+	}
+	for month := time.January; month <= time.December; month++ {
+		plan.Months = append(plan.Months, PlanMonth{Month: month})
+	}
+	for _, day := range result.Days {
+		month := &plan.Months[day.Date.Month()-1]
+		switch day.Kind {
+		case workdays.Vacation:
+			month.Vacation++
+			month.WorkingDays++
+		case workdays.Workday:
+			month.WorkingDays++
+			month.Billable++
+		}
+	}
+	return plan, nil
+}
+
+// FormatPlan renders the breaks, the billable days of every month with
+// their amount when a rate is set, and the vacation dates in the syntax of
+// --vacation.
+func FormatPlan(plan *VacationPlan, config *PlanConfig) string {
+	country := strings.ToUpper(config.Country)
+	if config.Region != "" {
+		country += "-" + strings.ToUpper(config.Region)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", tr.Sprintf("Vacation plan %d (%s): %s", config.Year, country,
+		tr.Plural("plan.vacation", config.Days-plan.Unused, config.Days-plan.Unused)))
+
+	if len(plan.Breaks) == 0 {
+		fmt.Fprintf(&b, "%s\n", tr.Text("No breaks planned."))
+	}
+	for _, br := range plan.Breaks {
+		fmt.Fprintf(&b, "%s %-9s – %s %-9s  %s\n", br.Start.Format("2006-01-02"), tr.Weekday(br.Start.Weekday()),
+			br.End.Format("2006-01-02"), tr.Weekday(br.End.Weekday()), tr.Sprintf("%s for %s",
+				tr.Plural("plan.off", br.Days(), br.Days()), tr.Plural("plan.vacation", len(br.Vacation), len(br.Vacation))))
+	}
+	if plan.Unused > 0 {
+		fmt.Fprintf(&b, "%s\n", tr.Plural("plan.unused", plan.Unused, plan.Unused))
+	}
+
+	total := PlanMonth{}
+	for _, month := range plan.Months {
+		total.WorkingDays += month.WorkingDays
+		total.Vacation += month.Vacation
+		total.Billable += month.Billable
+	}
+	amount := func(month PlanMonth) string {
+		return formatMoney(float64(month.Billable)*config.Rate, config.Currency)
+	}
+
+	columns := []string{tr.Text("Working days"), tr.Text("Vacation days"), tr.Text("Billable days")}
+	if config.Rate != 0 {
+		columns = append(columns, tr.Text("Amount"))
+	}
+	widths := make([]int, len(columns))
+	header := fmt.Sprintf("\n%-10s", tr.Text("Month"))
+	for i, column := range columns {
+		widths[i] = len([]rune(column))
+		if i == 3 {
+			widths[i] = max(widths[i], len([]rune(amount(total))))
+		}
+		header += fmt.Sprintf("  %*s", widths[i], column)
+	}
+	fmt.Fprintf(&b, "%s\n", header)
+
+	for _, month := range append(plan.Months, total) {
+		name := tr.Text("Total")
+		if month.Month != 0 {
+			name = i18n.Capitalize(tr.Month(month.Month, i18n.Nominative))
+		}
+		line := fmt.Sprintf("%-10s  %*d  %*d  %*d", name, widths[0], month.WorkingDays, widths[1], month.Vacation, widths[2], month.Billable)
+		if config.Rate != 0 {
+			line += fmt.Sprintf("  %*s", widths[3], amount(month))
+		}
+		fmt.Fprintf(&b, "%s\n", line)
+	}
+
+	var dates []string
+	for _, br := range plan.Breaks {
+		dates = append(dates, dateRanges(br.Vacation)...)
+	}
+	if len(dates) > 0 {
+		fmt.Fprintf(&b, "\n%s", tr.Sprintf("Vacation dates: %s", strings.Join(dates, ",")))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// dateRanges writes dates in order as single dates and ranges of
+// consecutive ones, e.g. "2025-04-22..2025-04-25".
+func dateRanges(dates []time.Time) []string {
+	var ranges []string
+	for i := 0; i < len(dates); {
+		j := i
+		for j+1 < len(dates) && dates[j+1].Equal(dates[j].AddDate(0, 0, 1)) {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, dates[i].Format("2006-01-02"))
+		} else {
+			ranges = append(ranges, dates[i].Format("2006-01-02")+".."+dates[j].Format("2006-01-02"))
+		}
+		i = j + 1
+	}
+	return ranges
+}
+
+const planHelp = `Usage: billme plan-vacation [year] [options]
+
+Suggest vacation dates for the year, the current one by default, that
+give the most consecutive days off: first the bridge days between
+weekends and holidays, then whole weeks spread over the year, then long
+weekends with the days left. The billable days of every month with the
+vacation taken, and their amount when a rate is known, show what the
+time off costs. The dates are printed in the syntax of --vacation, ready
+for the "vacation" list of the config file.
+
+Examples:
+  billme plan-vacation 2025 --days 25
+  billme plan-vacation --country DE --region BY --rate 6000 --currency EUR
+
+Options:
+  --days <n>                Vacation days to plan (default 20)
+  --rate <amount>           Daily rate for the amount of each month
+                            (default from the config file)
+  --currency <code>         Currency of the rate (default CZK)
+  --country <code>          Country whose holidays are days off (default CZ)
+                            or several joined by +, e.g. CZ+DE-BY
+  --region <code>           Region with its own holidays
+  --intersect               Only holidays in all the countries are days off
+  --holiday-types <types>   Types of holiday that are days off
+                            (default public,regional; all for every type)
+  --ignore-holidays         Treat holidays as working days
+  --work-week <days>        Working days of the week (default mon-fri)
+  --config <file>           Config file with the rate and currency
+  --today <YYYY-MM-DD>      Date used as today (or set BILLME_TODAY)
+  --lang <en|cs>            Output language (default from LANG)
+`
+
+func ShowPlanHelp() {
+	fmt.Print(tr.Text(planHelp))
+}
@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/i18n"
+	"github.com/honzahovorka/billme/pkg/workdays"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// Output formats of --output.
+const (
+	OutputMarkdown = "markdown"
+	OutputHTML     = "html"
+)
+
+// ReportMonth is one month of a --output report.
+type ReportMonth struct {
+	Name  string
+	Weeks [][]*calculator.Day // Monday first; nil where the month has no day
+}
+
+// ReportData is everything shown in a --output report.
+type ReportData struct {
+	Title        string
+	Months       []ReportMonth
+	Holidays     []calculator.Day // public holidays in the period, named in the output language
+	WorkingDays  int              // workdays before vacation is subtracted
+	HolidayDays  int              // public holidays excluded from the workdays
+	VacationDays int              // vacation days subtracted
+	Days         int              // billable days
+	Rate         float64
+	Amount       float64
+	Currency     string
+}
+
+// NewReportData builds the report from the calculation of the billed period.
+func NewReportData(result workdays.Result, config *Config) ReportData {
+	start, end := config.period()
+	days := int(result.Billable)
+	data := ReportData{
+		Title:        tr.Sprintf("Billable days report: %s", periodName(start, end, i18n.Nominative)),
+		WorkingDays:  result.WorkingDays,
+		HolidayDays:  result.Holidays,
+		VacationDays: result.WorkingDays - days,
+		Days:         days,
+		Rate:         config.Rate,
+		Amount:       PaymentAmount(days, config),
+		Currency:     config.Currency,
+	}
+
+	localized := localizeHolidays(result.Days)
+	for rest := localized; len(rest) > 0; {
+		n := daysIn(rest)
+		month := monthOf(rest[0].Date)
+		data.Months = append(data.Months, ReportMonth{
+			Name:  i18n.Capitalize(periodName(month, month, i18n.Nominative)),
+			Weeks: weeks(rest[:n]),
+		})
+		rest = rest[n:]
+	}
+	for _, day := range localized {
+		if day.Holiday != "" {
+			data.Holidays = append(data.Holidays, day)
+		}
+	}
+	return data
+}
+
+// localizeHolidays returns a copy of days whose Holiday names are in the
+// output language.
+func localizeHolidays(days []calculator.Day) []calculator.Day {
+	localized := make([]calculator.Day, len(days))
+	for i, day := range days {
+		if len(day.Holidays) > 0 {
+			day.Holiday = holidayName(day.Holidays[0])
+		}
+		localized[i] = day
+	}
+	return localized
+}
+
+// daysIn returns how many days at the start of days share the first one's
+// month.
+func daysIn(days []calculator.Day) int {
+	n := 1
+	for n < len(days) && days[n].Date.Month() == days[0].Date.Month() {
+		n++
+	}
+	return n
+}
+
+func monthOf(date time.Time) monthYear {
+	return monthYear{int(date.Month()), date.Year()}
+}
+
+// weeks lays the days of a month out in rows from Monday to Sunday.
+func weeks(month []calculator.Day) [][]*calculator.Day {
+	var rows [][]*calculator.Day
+	row := make([]*calculator.Day, (int(month[0].Date.Weekday())+6)%7)
+	for i := range month {
+		row = append(row, &month[i])
+		if len(row) == 7 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, append(row, make([]*calculator.Day, 7-len(row))...))
+	}
+	return rows
+}
+
+func weekdayHeader() []string {
+	return []string{tr.Text("Mo"), tr.Text("Tu"), tr.Text("We"), tr.Text("Th"), tr.Text("Fr"), tr.Text("Sa"), tr.Text("Su")}
+}
+
+// FormatDocument renders the report as output, OutputMarkdown or OutputHTML.
+func FormatDocument(output string, data ReportData) (string, error) {
+	switch output {
+	case OutputMarkdown:
+		return markdownReport(data), nil
+	case OutputHTML:
+		return htmlReport(data)
+	}
+	return "", tr.Errorf("unknown output format: %s (use markdown or html)", output)
+}
+
+func markdownReport(data ReportData) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", data.Title)
+
+	fmt.Fprintf(&b, "## %s\n\n", tr.Text("Summary"))
+	fmt.Fprintf(&b, "| | |\n|---|---:|\n")
+	fmt.Fprintf(&b, "| %s | %d |\n", tr.Text("Working days"), data.WorkingDays)
+	fmt.Fprintf(&b, "| %s | %d |\n", tr.Text("Public holidays"), data.HolidayDays)
+	fmt.Fprintf(&b, "| %s | %d |\n", tr.Text("Vacation days"), data.VacationDays)
+	fmt.Fprintf(&b, "| **%s** | **%d** |\n", tr.Text("Billable days"), data.Days)
+	if data.Rate != 0 {
+		fmt.Fprintf(&b, "| %s | %s |\n", tr.Text("Daily rate"), formatMoney(data.Rate, data.Currency))
+		fmt.Fprintf(&b, "| **%s** | **%s** |\n", tr.Text("Amount"), formatMoney(data.Amount, data.Currency))
+	}
+
+	for _, month := range data.Months {
+		fmt.Fprintf(&b, "\n## %s\n\n", month.Name)
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(weekdayHeader(), " | "))
+		fmt.Fprintf(&b, "|%s\n", strings.Repeat("---|", 7))
+		for _, week := range month.Weeks {
+			cells := make([]string, 7)
+			for i, day := range week {
+				cells[i] = markdownCell(day)
+			}
+			fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+	fmt.Fprintf(&b, "\n%s: _1_ %s · 1 🎉 %s · 1 🌴 %s\n",
+		tr.Text("Legend"), tr.Text("weekend"), tr.Text("public holiday"), tr.Text("vacation"))
+
+	fmt.Fprintf(&b, "\n## %s\n\n", tr.Text("Public holidays"))
+	if len(data.Holidays) == 0 {
+		fmt.Fprintf(&b, "%s\n", tr.Text("No public holidays."))
+	}
+	for _, day := range data.Holidays {
+		fmt.Fprintf(&b, "- %s %s: %s\n", tr.Weekday(day.Date.Weekday()), day.Date.Format("2006-01-02"), day.Holiday)
+	}
+	return b.String()
+}
+
+func markdownCell(day *calculator.Day) string {
+	if day == nil {
+		return ""
+	}
+	n := day.Date.Day()
+	switch day.Kind {
+	case calculator.Weekend:
+		return fmt.Sprintf("_%d_", n)
+	case calculator.Holiday:
+		return fmt.Sprintf("%d 🎉", n)
+	case calculator.Vacation:
+		return fmt.Sprintf("%d 🌴", n)
+	case calculator.Partial:
+		return fmt.Sprintf("%d 🌓", n)
+	}
+	return fmt.Sprint(n)
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"t":       func(s string) string { return tr.Text(s) },
+	"money":   formatMoney,
+	"weekday": func(d time.Weekday) string { return tr.Weekday(d) },
+	"header":  weekdayHeader,
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; max-width: 46em; margin: 2em auto; padding: 0 1em; }
+h1 { font-size: 1.6em; border-bottom: 2px solid #2e7d32; padding-bottom: .3em; }
+h2 { font-size: 1.2em; margin-top: 1.6em; }
+table { border-collapse: collapse; }
+.summary td { padding: .25em 1em .25em 0; }
+.summary td:last-child { text-align: right; }
+.summary .total td { font-weight: bold; border-top: 1px solid #999; }
+.calendar th, .calendar td { width: 3em; height: 2.2em; text-align: center; border: 1px solid #ddd; }
+.calendar th { background: #f5f5f5; font-weight: normal; color: #666; }
+.weekend { background: #f0f0f0; color: #999; }
+.holiday { background: #ffe0b2; }
+.vacation { background: #c8e6c9; }
+.partial { background: linear-gradient(135deg, #fff 50%, #c8e6c9 50%); }
+.legend span { display: inline-block; padding: .1em .6em; margin-right: .5em; border: 1px solid #ddd; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+
+<h2>{{t "Summary"}}</h2>
+<table class="summary">
+<tr><td>{{t "Working days"}}</td><td>{{.WorkingDays}}</td></tr>
+<tr><td>{{t "Public holidays"}}</td><td>{{.HolidayDays}}</td></tr>
+<tr><td>{{t "Vacation days"}}</td><td>{{.VacationDays}}</td></tr>
+<tr class="total"><td>{{t "Billable days"}}</td><td>{{.Days}}</td></tr>
+{{- if .Rate}}
+<tr><td>{{t "Daily rate"}}</td><td>{{money .Rate .Currency}}</td></tr>
+<tr class="total"><td>{{t "Amount"}}</td><td>{{money .Amount .Currency}}</td></tr>
+{{- end}}
+</table>
+{{range .Months}}
+<h2>{{.Name}}</h2>
+<table class="calendar">
+<tr>{{range header}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Weeks}}
+<tr>{{range .}}{{if .}}<td class="{{.Kind}}"{{with .Holiday}} title="{{.}}"{{end}}>{{.Date.Day}}</td>{{else}}<td></td>{{end}}{{end}}</tr>
+{{- end}}
+</table>
+{{end}}
+<p class="legend">{{t "Legend"}}: <span class="weekend">{{t "weekend"}}</span><span class="holiday">{{t "public holiday"}}</span><span class="vacation">{{t "vacation"}}</span></p>
+
+<h2>{{t "Public holidays"}}</h2>
+{{- if .Holidays}}
+<ul>
+{{- range .Holidays}}
+<li>{{weekday .Date.Weekday}} {{.Date.Format "2006-01-02"}}: {{.Holiday}}</li>
+{{- end}}
+</ul>
+{{- else}}
+<p>{{t "No public holidays."}}</p>
+{{- end}}
+</body>
+</html>
+`))
+
+func htmlReport(data ReportData) (string, error) {
+	var b strings.Builder
+	err := htmlTemplate.Execute(&b, struct {
+		ReportData
+		Lang string
+	}{data, string(tr.Lang)})
+	return b.String(), err
+}
@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/clock"
+	"github.com/honzahovorka/billme/internal/holidays"
+	"github.com/honzahovorka/billme/internal/i18n"
+	"strconv"
+	"time"
+)
+
+// CalendarConfig holds the options shared by the working-day commands.
+type CalendarConfig struct {
+	Country        string
+	Region         string
+	Intersect      bool // holidays of all the countries combined in Country, not any
+	IgnoreHolidays bool
+	HolidayTypes   holidays.HolidayTypes // days off; zero means the default types
+	WorkWeek       calculator.WorkWeek
+	Today          time.Time
+	Help           bool
+
+	today        string
+	lang         string
+	holidayTypes string
+}
+
+func (c *CalendarConfig) register(fs *flag.FlagSet) *string {
+	fs.StringVar(&c.today, "today", "", "pretend today is this date (YYYY-MM-DD)")
+	fs.StringVar(&c.lang, "lang", "", "output language: en or cs (default from LANG)")
+	fs.StringVar(&c.Country, "country", "CZ", "country whose holidays are skipped")
+	fs.StringVar(&c.Region, "region", "", "region of the country with its own holidays")
+	fs.BoolVar(&c.Intersect, "intersect", false, "skip only the days that are holidays in all the countries")
+	fs.BoolVar(&c.IgnoreHolidays, "ignore-holidays", false, "treat holidays as working days")
+	fs.StringVar(&c.holidayTypes, "holiday-types", "", "types of holiday that are days off, e.g. public,regional")
+	fs.BoolVar(&c.Help, "h", false, "show help")
+	fs.BoolVar(&c.Help, "help", false, "show help")
+	return fs.String("work-week", "mon-fri", "working days of the week, e.g. mon-thu")
+}
+
+func (c *CalendarConfig) finish(workWeek string) error {
+	week, err := calculator.ParseWorkWeek(workWeek)
+	if err != nil {
+		return err
+	}
+	c.WorkWeek = week
+
+	if c.holidayTypes != "" {
+		if c.HolidayTypes, err = holidays.ParseHolidayTypes(c.holidayTypes); err != nil {
+			return Localize(err)
+		}
+	}
+
+	now, err := resolveClock(c.today)
+	if err != nil {
+		return err
+	}
+	c.Today = clock.Today(now)
+	return nil
+}
+
+// Calendar returns the working-day calendar of the options: the work week
+// and, unless holidays are ignored, the days off of the country and region.
+func (c *CalendarConfig) Calendar() (*calculator.Calendar, error) {
+	var provider holidays.HolidayProvider
+	if !c.IgnoreHolidays {
+		var err error
+		if provider, err = calculator.ResolveProvider(c.Country, c.Region, c.Intersect, c.HolidayTypes); err != nil {
+			return nil, err
+		}
+	}
+	calendar := calculator.NewCalendar(provider)
+	calendar.WorkWeek = c.WorkWeek
+	calendar.Region = c.Region
+	calendar.HolidayTypes = c.HolidayTypes
+	return calendar, nil
+}
+
+// ShiftConfig holds the arguments of "billme shift <date> <±N>".
+type ShiftConfig struct {
+	CalendarConfig
+	Date time.Time
+	Days int
+}
+
+// ParseShiftArgs parses "billme shift <date> <±N> [options]".
+func ParseShiftArgs(args []string) (*ShiftConfig, error) {
+	config := &ShiftConfig{}
+
+	fs := flag.NewFlagSet("shift", flag.ContinueOnError)
+	fs.SetOutput(nopWriter{})
+	workWeek := config.register(fs)
+
+	detectLanguage()
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := setLanguage(config.lang); err != nil {
+		return nil, err
+	}
+	if config.Help {
+		return config, nil
+	}
+	if err := config.finish(*workWeek); err != nil {
+		return nil, err
+	}
+
+	if len(positional) != 2 {
+		return nil, tr.Errorf("usage: billme shift <YYYY-MM-DD> <±days>")
+	}
+	config.Date, err = time.Parse("2006-01-02", positional[0])
+	if err != nil {
+		return nil, tr.Errorf("invalid date: %s", positional[0])
+	}
+	config.Days, err = strconv.Atoi(positional[1])
+	if err != nil {
+		return nil, tr.Errorf("invalid number of days: %s", positional[1])
+	}
+
+	return config, nil
+}
+
+// NthConfig holds the arguments of "billme nth" and "billme last-workday".
+// N counts from the end of the month when negative.
+type NthConfig struct {
+	CalendarConfig
+	N     int
+	Month int
+	Year  int
+}
+
+// ParseNthArgs parses "billme nth <n> [month] [year] [options]".
+func ParseNthArgs(args []string) (*NthConfig, error) {
+	config, positional, err := parseNthFlags("nth", args)
+	if err != nil || config.Help {
+		return config, err
+	}
+
+	if len(positional) == 0 {
+		return nil, tr.Errorf("usage: billme nth <n> [month] [year]")
+	}
+	config.N, err = strconv.Atoi(positional[0])
+	if err != nil || config.N == 0 {
+		return nil, tr.Errorf("invalid n: %s", positional[0])
+	}
+
+	config.Month, config.Year, err = parseMonthYear(positional[1:], config.Today)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ParseLastWorkdayArgs parses "billme last-workday [month] [year] [options]".
+func ParseLastWorkdayArgs(args []string) (*NthConfig, error) {
+	config, positional, err := parseNthFlags("last-workday", args)
+	if err != nil || config.Help {
+		return config, err
+	}
+
+	config.N = -1
+	config.Month, config.Year, err = parseMonthYear(positional, config.Today)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func parseNthFlags(name string, args []string) (*NthConfig, []string, error) {
+	config := &NthConfig{}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(nopWriter{})
+	workWeek := config.register(fs)
+
+	detectLanguage()
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := setLanguage(config.lang); err != nil {
+		return nil, nil, err
+	}
+	if config.Help {
+		return config, nil, nil
+	}
+	if err := config.finish(*workWeek); err != nil {
+		return nil, nil, err
+	}
+	return config, positional, nil
+}
+
+const workdaysHelp = `Usage: billme shift <YYYY-MM-DD> <±days> [options]
+       billme nth <n> [month] [year] [options]
+       billme last-workday [month] [year] [options]
+
+Working-day arithmetic that skips weekends and holidays.
+
+Examples:
+  billme shift 2024-07-01 10      # 10 working days after July 1
+  billme shift 2024-07-08 -1      # working day before July 8
+  billme nth 1 1 2024             # first working day of January 2024
+  billme nth -2 12                # second to last working day of December
+  billme last-workday             # invoicing day this month
+
+Options:
+  --country <code>          Country whose holidays are skipped (default CZ)
+                            or several joined by +, e.g. CZ+DE-BY
+  --region <code>           Region with its own holidays
+  --intersect               Skip only holidays in all the countries
+  --holiday-types <types>   Types of holiday skipped (default public,regional)
+  --ignore-holidays         Treat holidays as working days
+  --work-week <days>        Working days of the week (default mon-fri)
+  --today <YYYY-MM-DD>      Date used as today (or set BILLME_TODAY)
+  --lang <en|cs>            Output language (default from LANG)
+`
+
+func ShowWorkdaysHelp() {
+	fmt.Print(tr.Text(workdaysHelp))
+}
+
+// TooFewWorkingDays is the error for an nth working day the month lacks.
+func TooFewWorkingDays(config *NthConfig) error {
+	month := monthYear{config.Month, config.Year}
+	n := abs(config.N)
+	return errors.New(tr.Plural("nth.fewer", n,
+		periodName(month, month, i18n.Nominative), periodName(month, month, i18n.Locative), n))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// FormatDate renders a date result of the working-day commands.
+func FormatDate(day time.Time) string {
+	return day.Format("2006-01-02")
+}
@@ -0,0 +1,64 @@
+package clock
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// EnvToday names the environment variable that pins today's date, e.g.
+// BILLME_TODAY=2024-07-15, so reports can be reproduced as of a past day.
+const EnvToday = "BILLME_TODAY"
+
+// Clock tells the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the real wall clock.
+type System struct{}
+
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed is a clock stopped at a single instant.
+type Fixed time.Time
+
+func (f Fixed) Now() time.Time {
+	return time.Time(f)
+}
+
+// Today returns the current date of c at midnight UTC, the form used for
+// all calendar arithmetic.
+func Today(c Clock) time.Time {
+	now := c.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DateError reports a date for today that is not in the YYYY-MM-DD form.
+type DateError struct {
+	Value string
+}
+
+func (e *DateError) Error() string {
+	return fmt.Sprintf("invalid today date: %s", e.Value)
+}
+
+// Resolve picks the clock for a run: the date given on the command line,
+// then $BILLME_TODAY, then fallback. An invalid date is reported with a
+// *DateError.
+func Resolve(today string, fallback Clock) (Clock, error) {
+	if today == "" {
+		today = os.Getenv(EnvToday)
+	}
+	if today == "" {
+		return fallback, nil
+	}
+
+	date, err := time.Parse("2006-01-02", today)
+	if err != nil {
+		return nil, &DateError{Value: today}
+	}
+	return Fixed(date), nil
+}
@@ -0,0 +1,69 @@
+package clock
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestToday(t *testing.T) {
+	c := Fixed(time.Date(2024, 7, 15, 23, 45, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	want := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+	if got := Today(c); !got.Equal(want) {
+		t.Errorf("Today() = %v, want %v", got, want)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	fallback := Fixed(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		flag     string
+		env      string
+		expected time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "Fallback",
+			expected: time.Time(fallback),
+		},
+		{
+			name:     "Environment",
+			env:      "2024-07-15",
+			expected: time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Flag wins over environment",
+			flag:     "2024-02-29",
+			env:      "2024-07-15",
+			expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Invalid date",
+			flag:    "15.7.2024",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvToday, tt.env)
+
+			c, err := Resolve(tt.flag, fallback)
+			if tt.wantErr {
+				var dateErr *DateError
+				if !errors.As(err, &dateErr) || dateErr.Value != tt.flag {
+					t.Errorf("Resolve() error = %v; want a *DateError for %s", err, tt.flag)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error: %v", err)
+			}
+			if got := c.Now(); !got.Equal(tt.expected) {
+				t.Errorf("Now() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package holidays
+
+import "time"
+
+// BulgarianHolidayProvider supplies the days off of Bulgaria, with Easter
+// kept by the Orthodox calendar. A holiday other than Easter that falls on
+// a weekend gives the first working day after it off.
+type BulgarianHolidayProvider struct{}
+
+func (p *BulgarianHolidayProvider) GetHolidays(year int) []Holiday {
+	easter := calculateOrthodoxEaster(year)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	holidays := []Holiday{
+		{ID: "new-years-day", Name: "Нова година", EnglishName: "New Year's Day", Date: date(1, 1)},
+		{ID: "liberation-day", Name: "Ден на Освобождението на България", EnglishName: "Liberation Day", Date: date(3, 3)},
+		{ID: "good-friday", Name: "Велики петък", EnglishName: "Orthodox Good Friday", Date: easter.AddDate(0, 0, -2)},
+		{ID: "holy-saturday", Name: "Велика събота", EnglishName: "Orthodox Holy Saturday", Date: easter.AddDate(0, 0, -1)},
+		{ID: "easter-sunday", Name: "Великден", EnglishName: "Orthodox Easter Sunday", Date: easter},
+		{ID: "easter-monday", Name: "Великден, втори ден", EnglishName: "Orthodox Easter Monday", Date: easter.AddDate(0, 0, 1)},
+		{ID: "labour-day", Name: "Ден на труда", EnglishName: "Labour Day", Date: date(5, 1)},
+		{ID: "st-georges-day", Name: "Гергьовден", EnglishName: "St. George's Day", Date: date(5, 6)},
+		{ID: "culture-day", Name: "Ден на българската просвета и култура и на славянската писменост", EnglishName: "Bulgarian Education and Culture Day", Date: date(5, 24)},
+		{ID: "unification-day", Name: "Ден на Съединението", EnglishName: "Unification Day", Date: date(9, 6)},
+		{ID: "independence-day", Name: "Ден на Независимостта", EnglishName: "Independence Day", Date: date(9, 22)},
+		{ID: "christmas-eve", Name: "Бъдни вечер", EnglishName: "Christmas Eve", Date: date(12, 24)},
+		{ID: "christmas-day", Name: "Коледа", EnglishName: "Christmas Day", Date: date(12, 25)},
+		{ID: "second-day-of-christmas", Name: "Коледа, втори ден", EnglishName: "Second Day of Christmas", Date: date(12, 26)},
+	}
+
+	weekend := func(day time.Weekday) bool { return day == time.Saturday || day == time.Sunday }
+	notEaster := func(holiday Holiday) bool {
+		return holiday.Date.Before(easter.AddDate(0, 0, -2)) || holiday.Date.After(easter.AddDate(0, 0, 1))
+	}
+	holidays = append(holidays, substituteDays(holidays, weekend, notEaster, "почивен ден")...)
+
+	for i := range holidays {
+		holidays[i].Language = "bg"
+		holidays[i].Source = "Кодекс на труда, чл. 154"
+	}
+	return holidays
+}
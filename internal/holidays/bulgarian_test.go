@@ -0,0 +1,38 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBulgarianHolidayProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		year     int
+		id       string
+		expected []string
+	}{
+		{"Orthodox Easter", 2024, "easter-sunday", []string{"2024-05-05"}},
+		{"Good Friday", 2024, "good-friday", []string{"2024-05-03"}},
+		{"Liberation Day on Sunday", 2024, "liberation-day-substitute", []string{"2024-03-04"}},
+		{"Independence Day on Sunday", 2024, "independence-day-substitute", []string{"2024-09-23"}},
+		{"Christmas on a weekend", 2021, "christmas-day-substitute", []string{"2021-12-27"}},
+		{"Second day of Christmas on a weekend", 2021, "second-day-of-christmas-substitute", []string{"2021-12-28"}},
+		{"Easter is not moved", 2024, "easter-sunday-substitute", nil},
+		{"Labour Day on Holy Saturday", 2021, "labour-day-substitute", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dates []string
+			for _, holiday := range (&BulgarianHolidayProvider{}).GetHolidays(tt.year) {
+				if holiday.ID == tt.id {
+					dates = append(dates, holiday.Date.Format(time.DateOnly))
+				}
+			}
+			if len(dates) != len(tt.expected) || len(dates) > 0 && dates[0] != tt.expected[0] {
+				t.Errorf("%s in %d on %v; want %v", tt.id, tt.year, dates, tt.expected)
+			}
+		})
+	}
+}
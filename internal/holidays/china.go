@@ -0,0 +1,187 @@
+package holidays
+
+import "time"
+
+// ChineseHolidayProvider supplies the holidays of China, with the Spring,
+// Dragon Boat and Mid-Autumn festivals kept by the Chinese calendar. Like
+// ChineseDate, it is exact from 1900 to 2100 only; the festivals of other
+// years are approximate.
+//
+// Every year the State Council joins the holidays and the weekends around
+// them into week-long breaks: weekdays are given off as rest days and made
+// up on weekend days, which are working days. The schedules of the years
+// below are known; other years have the statutory holidays only.
+type ChineseHolidayProvider struct{}
+
+// chineseBreak is a break of the State Council schedule: the days from
+// first through last are off, and the working days are worked instead.
+type chineseBreak struct {
+	id, name, englishName string
+	first, last           string
+	workingDays           []string
+}
+
+var chineseSchedules = map[int]struct {
+	source string
+	breaks []chineseBreak
+}{
+	2023: {"国办发明电〔2022〕16号", []chineseBreak{
+		{"new-years-day", "元旦", "New Year's Day", "2022-12-31", "2023-01-02", nil},
+		{"spring-festival", "春节", "Spring Festival", "2023-01-21", "2023-01-27", []string{"2023-01-28", "2023-01-29"}},
+		{"qingming-festival", "清明节", "Qingming Festival", "2023-04-05", "2023-04-05", nil},
+		{"labour-day", "劳动节", "Labour Day", "2023-04-29", "2023-05-03", []string{"2023-04-23", "2023-05-06"}},
+		{"dragon-boat-festival", "端午节", "Dragon Boat Festival", "2023-06-22", "2023-06-24", []string{"2023-06-25"}},
+		{"national-day", "国庆节", "National Day", "2023-09-29", "2023-10-06", []string{"2023-10-07", "2023-10-08"}},
+	}},
+	2024: {"国办发明电〔2023〕7号", []chineseBreak{
+		{"new-years-day", "元旦", "New Year's Day", "2023-12-30", "2024-01-01", nil},
+		{"spring-festival", "春节", "Spring Festival", "2024-02-10", "2024-02-17", []string{"2024-02-04", "2024-02-18"}},
+		{"qingming-festival", "清明节", "Qingming Festival", "2024-04-04", "2024-04-06", []string{"2024-04-07"}},
+		{"labour-day", "劳动节", "Labour Day", "2024-05-01", "2024-05-05", []string{"2024-04-28", "2024-05-11"}},
+		{"dragon-boat-festival", "端午节", "Dragon Boat Festival", "2024-06-08", "2024-06-10", nil},
+		{"mid-autumn-festival", "中秋节", "Mid-Autumn Festival", "2024-09-15", "2024-09-17", []string{"2024-09-14"}},
+		{"national-day", "国庆节", "National Day", "2024-10-01", "2024-10-07", []string{"2024-09-29", "2024-10-12"}},
+	}},
+	2025: {"国办发明电〔2024〕12号", []chineseBreak{
+		{"new-years-day", "元旦", "New Year's Day", "2025-01-01", "2025-01-01", nil},
+		{"spring-festival", "春节", "Spring Festival", "2025-01-28", "2025-02-04", []string{"2025-01-26", "2025-02-08"}},
+		{"qingming-festival", "清明节", "Qingming Festival", "2025-04-04", "2025-04-06", nil},
+		{"labour-day", "劳动节", "Labour Day", "2025-05-01", "2025-05-05", []string{"2025-04-27"}},
+		{"dragon-boat-festival", "端午节", "Dragon Boat Festival", "2025-05-31", "2025-06-02", nil},
+		{"national-day", "国庆节", "National Day", "2025-10-01", "2025-10-08", []string{"2025-09-28", "2025-10-11"}},
+	}},
+	2026: {"国办发明电〔2025〕7号", []chineseBreak{
+		{"new-years-day", "元旦", "New Year's Day", "2026-01-01", "2026-01-03", []string{"2026-01-04"}},
+		{"spring-festival", "春节", "Spring Festival", "2026-02-15", "2026-02-23", []string{"2026-02-14", "2026-02-28"}},
+		{"qingming-festival", "清明节", "Qingming Festival", "2026-04-04", "2026-04-06", nil},
+		{"labour-day", "劳动节", "Labour Day", "2026-05-01", "2026-05-05", []string{"2026-05-09"}},
+		{"dragon-boat-festival", "端午节", "Dragon Boat Festival", "2026-06-19", "2026-06-21", nil},
+		{"mid-autumn-festival", "中秋节", "Mid-Autumn Festival", "2026-09-25", "2026-09-27", nil},
+		{"national-day", "国庆节", "National Day", "2026-10-01", "2026-10-07", []string{"2026-09-20", "2026-10-10"}},
+	}},
+}
+
+func (p *ChineseHolidayProvider) GetHolidays(year int) []Holiday {
+	holidays := chineseStatutoryHolidays(year)
+
+	statutory := map[time.Time]bool{}
+	for _, holiday := range holidays {
+		statutory[holiday.Date] = true
+	}
+	// A break of next year's schedule may begin in this one.
+	for _, scheduleYear := range []int{year, year + 1} {
+		schedule := chineseSchedules[scheduleYear]
+		for _, b := range schedule.breaks {
+			last := parseDate(b.last)
+			for date := parseDate(b.first); !date.After(last); date = date.AddDate(0, 0, 1) {
+				if date.Year() != year || statutory[date] || date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+					continue
+				}
+				holidays = append(holidays, Holiday{
+					ID:          b.id + "-rest-day",
+					Name:        b.name + " (调休)",
+					EnglishName: b.englishName + " (rest day)",
+					Date:        date,
+					Source:      schedule.source,
+				})
+			}
+		}
+	}
+
+	for i := range holidays {
+		holidays[i].Language = "zh"
+		if holidays[i].Source == "" {
+			holidays[i].Source = "全国年节及纪念日放假办法"
+		}
+	}
+	return holidays
+}
+
+// HasSchedule reports whether the State Council schedule of year is known.
+func (p *ChineseHolidayProvider) HasSchedule(year int) bool {
+	_, ok := chineseSchedules[year]
+	return ok
+}
+
+// GetWorkingDays returns the weekend days the State Council schedule of
+// year makes working days.
+func (p *ChineseHolidayProvider) GetWorkingDays(year int) []Holiday {
+	var days []Holiday
+	for _, scheduleYear := range []int{year, year + 1} {
+		schedule := chineseSchedules[scheduleYear]
+		for _, b := range schedule.breaks {
+			for _, value := range b.workingDays {
+				if date := parseDate(value); date.Year() == year {
+					days = append(days, Holiday{
+						ID:          b.id + "-working-day",
+						Name:        b.name + " (调休上班)",
+						EnglishName: b.englishName + " (working day)",
+						Language:    "zh",
+						Date:        date,
+						Source:      schedule.source,
+					})
+				}
+			}
+		}
+	}
+	return days
+}
+
+// chineseStatutoryHolidays returns the holidays of the State Council's
+// "National Holidays and Memorial Days" regulations as they stood in year:
+// those of 1949, extended in 1999, 2007, 2013 and 2024.
+func chineseStatutoryHolidays(year int) []Holiday {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	newYear := LunarNewYear(year)
+
+	holidays := []Holiday{
+		{ID: "new-years-day", Name: "元旦", EnglishName: "New Year's Day", Date: date(1, 1)},
+		{ID: "labour-day", Name: "劳动节", EnglishName: "Labour Day", Date: date(5, 1)},
+		{ID: "national-day", Name: "国庆节", EnglishName: "National Day", Date: date(10, 1)},
+		{ID: "national-day-2", Name: "国庆节 (第二天)", EnglishName: "National Day (second day)", Date: date(10, 2)},
+	}
+	if year >= 2000 {
+		holidays = append(holidays, Holiday{ID: "national-day-3", Name: "国庆节 (第三天)", EnglishName: "National Day (third day)", Date: date(10, 3)})
+	}
+
+	// The Spring Festival began on New Year's Eve in 2008-2013, and has
+	// since 2025.
+	springFestival := []Holiday{
+		{ID: "spring-festival-eve", Name: "除夕", EnglishName: "Spring Festival Eve", Date: newYear.AddDate(0, 0, -1)},
+		{ID: "spring-festival", Name: "春节", EnglishName: "Spring Festival", Date: newYear},
+		{ID: "spring-festival-2", Name: "春节 (初二)", EnglishName: "Spring Festival (second day)", Date: newYear.AddDate(0, 0, 1)},
+		{ID: "spring-festival-3", Name: "春节 (初三)", EnglishName: "Spring Festival (third day)", Date: newYear.AddDate(0, 0, 2)},
+	}
+	switch {
+	case year >= 2025:
+	case year >= 2008 && year <= 2013:
+		springFestival = springFestival[:3]
+	default:
+		springFestival = springFestival[1:]
+	}
+	holidays = append(holidays, springFestival...)
+
+	switch {
+	case year >= 2000 && year <= 2007:
+		holidays = append(holidays,
+			Holiday{ID: "labour-day-2", Name: "劳动节 (第二天)", EnglishName: "Labour Day (second day)", Date: date(5, 2)},
+			Holiday{ID: "labour-day-3", Name: "劳动节 (第三天)", EnglishName: "Labour Day (third day)", Date: date(5, 3)})
+	case year >= 2025:
+		holidays = append(holidays, Holiday{ID: "labour-day-2", Name: "劳动节 (第二天)", EnglishName: "Labour Day (second day)", Date: date(5, 2)})
+	}
+
+	if year >= 2008 {
+		holidays = append(holidays,
+			Holiday{ID: "qingming-festival", Name: "清明节", EnglishName: "Qingming Festival", Date: Qingming(year)},
+			Holiday{ID: "dragon-boat-festival", Name: "端午节", EnglishName: "Dragon Boat Festival", Date: ChineseDate(year, 5, 5)},
+			Holiday{ID: "mid-autumn-festival", Name: "中秋节", EnglishName: "Mid-Autumn Festival", Date: ChineseDate(year, 8, 15)})
+	}
+	return holidays
+}
+
+func parseDate(value string) time.Time {
+	date, _ := time.Parse("2006-01-02", value)
+	return date
+}
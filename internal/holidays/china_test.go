@@ -0,0 +1,148 @@
+package holidays
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChineseHolidayProvider(t *testing.T) {
+	provider := &ChineseHolidayProvider{}
+
+	tests := []struct {
+		year     int
+		expected int
+	}{
+		{1999, 7},  // New Year, Spring Festival, Labour Day, National Day
+		{2007, 10}, // three days at Labour Day and National Day
+		{2012, 11}, // the Spring Festival from New Year's Eve
+		{2022, 11},
+		{2023, 21}, // and 10 rest days of the State Council schedule
+		{2024, 21},
+		{2025, 19}, // New Year's Eve, a second day at Labour Day and 6 rest days
+		{2026, 22},
+		{2027, 13}, // no schedule
+	}
+	for _, tt := range tests {
+		if got := len(provider.GetHolidays(tt.year)); got != tt.expected {
+			t.Errorf("GetHolidays(%d) returned %d holidays; want %d", tt.year, got, tt.expected)
+		}
+	}
+
+	expected := map[string]string{
+		"spring-festival":      "2024-02-10",
+		"qingming-festival":    "2024-04-04",
+		"dragon-boat-festival": "2024-06-10",
+		"mid-autumn-festival":  "2024-09-17",
+	}
+	for _, holiday := range provider.GetHolidays(2024) {
+		if holiday.Language != "zh" || holiday.EnglishName == "" || holiday.Source == "" {
+			t.Errorf("%s has incomplete metadata: %+v", holiday.Name, holiday)
+		}
+		if date, ok := expected[holiday.ID]; ok && holiday.Date.Format(time.DateOnly) != date {
+			t.Errorf("%s on %s; want %s", holiday.ID, holiday.Date.Format(time.DateOnly), date)
+		}
+	}
+}
+
+func TestChineseSchedules(t *testing.T) {
+	for year, schedule := range chineseSchedules {
+		breaks := map[string]chineseBreak{}
+		for _, b := range schedule.breaks {
+			breaks[b.id] = b
+			for _, value := range b.workingDays {
+				if date := parseDate(value); date.Weekday() != time.Saturday && date.Weekday() != time.Sunday {
+					t.Errorf("%s working day %s is a weekday", b.id, value)
+				}
+			}
+		}
+
+		// The statutory holidays computed from the calendars fall within the
+		// breaks the State Council published for them.
+		for _, holiday := range chineseStatutoryHolidays(year) {
+			b, ok := breaks[strings.TrimRight(strings.TrimSuffix(holiday.ID, "-eve"), "-23")]
+			if !ok {
+				b = breaks["national-day"] // joined by the Mid-Autumn Festival
+			}
+			if holiday.Date.Before(parseDate(b.first)) || holiday.Date.After(parseDate(b.last)) {
+				t.Errorf("%d: %s on %s is outside its break %s to %s", year, holiday.ID, holiday.Date.Format(time.DateOnly), b.first, b.last)
+			}
+		}
+	}
+}
+
+func TestChineseWorkingDays(t *testing.T) {
+	provider := &ChineseHolidayProvider{}
+	tests := []struct {
+		year     int
+		expected []string
+	}{
+		{2022, nil},
+		{2024, []string{"2024-02-04", "2024-02-18", "2024-04-07", "2024-04-28", "2024-05-11", "2024-09-14", "2024-09-29", "2024-10-12"}},
+		{2026, []string{"2026-01-04", "2026-02-14", "2026-02-28", "2026-05-09", "2026-09-20", "2026-10-10"}},
+	}
+	for _, tt := range tests {
+		days := provider.GetWorkingDays(tt.year)
+		if len(days) != len(tt.expected) {
+			t.Fatalf("GetWorkingDays(%d) returned %d days; want %d", tt.year, len(days), len(tt.expected))
+		}
+		for i, day := range days {
+			if got := day.Date.Format(time.DateOnly); got != tt.expected[i] || day.Language != "zh" || day.EnglishName == "" {
+				t.Errorf("GetWorkingDays(%d)[%d] = %s %q; want %s", tt.year, i, got, day.EnglishName, tt.expected[i])
+			}
+		}
+	}
+
+	// The rest days of 2024's New Year break fall in December 2023.
+	for _, holiday := range provider.GetHolidays(2023) {
+		if holiday.ID == "new-years-day-rest-day" && holiday.Date.Year() != 2023 {
+			t.Errorf("rest day %s listed in 2023", holiday.Date.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestChineseHasSchedule(t *testing.T) {
+	provider := &ChineseHolidayProvider{}
+	for year, expected := range map[int]bool{2022: false, 2023: true, 2026: true, 2027: false} {
+		if got := provider.HasSchedule(year); got != expected {
+			t.Errorf("HasSchedule(%d) = %v; want %v", year, got, expected)
+		}
+	}
+
+	combined := &CombinedProvider{Jurisdictions: []Jurisdiction{{Code: "CZ", Provider: &CzechHolidayProvider{}}, {Code: "CN", Provider: provider}}}
+	if !HasSchedule(&CzechHolidayProvider{}, 2027) || HasSchedule(combined, 2027) || !HasSchedule(combined, 2026) {
+		t.Errorf("HasSchedule of CZ and CZ+CN in 2026-2027 is wrong")
+	}
+}
+
+func TestChineseDate(t *testing.T) {
+	tests := []struct {
+		year, month, day int
+		expected         string
+	}{
+		{2017, 8, 15, "2017-10-04"}, // after a leap sixth month
+		{2020, 5, 5, "2020-06-25"},  // after a leap fourth month
+		{2023, 5, 5, "2023-06-22"},  // after a leap second month
+		{2024, 1, 15, "2024-02-24"}, // Lantern Festival
+		{2025, 8, 15, "2025-10-06"}, // after a leap sixth month
+		{2026, 5, 5, "2026-06-19"},
+	}
+	for _, tt := range tests {
+		if got := ChineseDate(tt.year, tt.month, tt.day).Format(time.DateOnly); got != tt.expected {
+			t.Errorf("ChineseDate(%d, %d, %d) = %s; want %s", tt.year, tt.month, tt.day, got, tt.expected)
+		}
+	}
+}
+
+func TestQingming(t *testing.T) {
+	expected := []string{
+		"2015-04-05", "2016-04-04", "2017-04-04", "2018-04-05", "2019-04-05",
+		"2020-04-04", "2021-04-04", "2022-04-05", "2023-04-05", "2024-04-04",
+		"2025-04-04", "2026-04-05",
+	}
+	for i, date := range expected {
+		if got := Qingming(2015 + i).Format(time.DateOnly); got != date {
+			t.Errorf("Qingming(%d) = %s; want %s", 2015+i, got, date)
+		}
+	}
+}
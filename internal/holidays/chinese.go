@@ -0,0 +1,247 @@
+package holidays
+
+import (
+	"math"
+	"time"
+)
+
+// The Chinese calendar is astronomical: a month begins on the day of the
+// new moon in Beijing, and month 11 holds the winter solstice. The new
+// moons follow Meeus, "Astronomical Algorithms", chapter 49, and the Sun's
+// longitude its chapter 25, good to 0.01° or about a quarter of an hour,
+// which places the new year correctly in every year from 1900 to 2100.
+//
+// Moments are Julian days in Universal Time unless named ephemeris (TT).
+
+const (
+	j2000         = 2451545.0 // 2000-01-01 12:00 TT
+	unixEpochJD   = 2440587.5 // 1970-01-01 00:00 UT
+	synodicMonth  = 29.530588861
+	firstNewMoonK = 2451550.09766 // ephemeris new moon of January 6, 2000, lunation 0
+)
+
+func sinDeg(degrees float64) float64 {
+	return math.Sin(degrees * math.Pi / 180)
+}
+
+// deltaT approximates TT − UT in days with the long-term parabola of
+// Morrison and Stephenson, within a minute over the supported years.
+func deltaT(jd float64) float64 {
+	u := ((jd-j2000)/365.25 + 2000 - 1820) / 100
+	return (-20 + 32*u*u) / 86400
+}
+
+// newMoon returns the moment of lunation k, counted from January 6, 2000.
+func newMoon(k int) float64 {
+	kf := float64(k)
+	t := kf / 1236.85
+	jde := firstNewMoonK + synodicMonth*kf + 0.00015437*t*t - 0.000000150*t*t*t + 0.00000000073*t*t*t*t
+
+	e := 1 - 0.002516*t - 0.0000074*t*t
+	m := 2.5534 + 29.10535670*kf - 0.0000014*t*t - 0.00000011*t*t*t
+	mp := 201.5643 + 385.81693528*kf + 0.0107582*t*t + 0.00001238*t*t*t - 0.000000058*t*t*t*t
+	f := 160.7108 + 390.67050284*kf - 0.0016118*t*t - 0.00000227*t*t*t + 0.000000011*t*t*t*t
+	omega := 124.7746 - 1.56375588*kf + 0.0020672*t*t + 0.00000215*t*t*t
+
+	jde += -0.40720*sinDeg(mp) +
+		0.17241*e*sinDeg(m) +
+		0.01608*sinDeg(2*mp) +
+		0.01039*sinDeg(2*f) +
+		0.00739*e*sinDeg(mp-m) -
+		0.00514*e*sinDeg(mp+m) +
+		0.00208*e*e*sinDeg(2*m) -
+		0.00111*sinDeg(mp-2*f) -
+		0.00057*sinDeg(mp+2*f) +
+		0.00056*e*sinDeg(2*mp+m) -
+		0.00042*sinDeg(3*mp) +
+		0.00042*e*sinDeg(m+2*f) +
+		0.00038*e*sinDeg(m-2*f) -
+		0.00024*e*sinDeg(2*mp-m) -
+		0.00017*sinDeg(omega) -
+		0.00007*sinDeg(mp+2*m) +
+		0.00004*sinDeg(2*mp-2*f) +
+		0.00004*sinDeg(3*m) +
+		0.00003*sinDeg(mp+m-2*f) +
+		0.00003*sinDeg(2*mp+2*f) -
+		0.00003*sinDeg(mp+m+2*f) +
+		0.00003*sinDeg(mp-m+2*f) -
+		0.00002*sinDeg(mp-m-2*f) -
+		0.00002*sinDeg(3*mp+m) +
+		0.00002*sinDeg(4*mp)
+
+	// Planetary perturbations.
+	planetary := [...][3]float64{
+		{299.77, 0.107408, 0.000325}, {251.88, 0.016321, 0.000165}, {251.83, 26.651886, 0.000164},
+		{349.42, 36.412478, 0.000126}, {84.66, 18.206239, 0.000110}, {141.74, 53.303771, 0.000062},
+		{207.14, 2.453732, 0.000060}, {154.84, 7.306860, 0.000056}, {34.52, 27.261239, 0.000047},
+		{207.19, 0.121824, 0.000042}, {291.34, 1.844379, 0.000040}, {161.72, 24.198154, 0.000037},
+		{239.56, 25.513099, 0.000035}, {331.55, 3.592518, 0.000023},
+	}
+	for i, term := range planetary {
+		argument := term[0] + term[1]*kf
+		if i == 0 {
+			argument -= 0.009173 * t * t
+		}
+		jde += term[2] * sinDeg(argument)
+	}
+
+	return jde - deltaT(jde)
+}
+
+// newMoonAtOrAfter returns the first new moon at or after the moment.
+func newMoonAtOrAfter(jd float64) float64 {
+	k := int(math.Floor((jd-firstNewMoonK)/synodicMonth)) - 1
+	for newMoon(k) < jd {
+		k++
+	}
+	return newMoon(k)
+}
+
+// solarLongitude returns the apparent longitude of the Sun in degrees,
+// 0 at the March equinox and 270 at the December solstice.
+func solarLongitude(jd float64) float64 {
+	t := (jd + deltaT(jd) - j2000) / 36525
+	l0 := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	m := 357.52911 + 35999.05029*t - 0.0001537*t*t
+	c := (1.914602-0.004817*t-0.000014*t*t)*sinDeg(m) + (0.019993-0.000101*t)*sinDeg(2*m) + 0.000289*sinDeg(3*m)
+	omega := 125.04 - 1934.136*t
+	return math.Mod(math.Mod(l0+c-0.00569-0.00478*sinDeg(omega), 360)+360, 360)
+}
+
+// chinaOffset is the offset of Chinese standard time, in days: Beijing
+// mean time until 1928, UTC+8 since.
+func chinaOffset(day int) float64 {
+	if fromFixed(day).Year() < 1929 {
+		return (1397.0 / 180) / 24
+	}
+	return 8.0 / 24
+}
+
+// midnightInChina returns the moment a day starts in Beijing.
+func midnightInChina(day int) float64 {
+	return float64(day-unixEpochFixed) + unixEpochJD - chinaOffset(day)
+}
+
+// chinaDay returns the day in Beijing at the moment.
+func chinaDay(jd float64) int {
+	day := int(math.Floor(jd-unixEpochJD)) + unixEpochFixed
+	return int(math.Floor(jd+chinaOffset(day)-unixEpochJD)) + unixEpochFixed
+}
+
+// chineseNewMoonOnOrAfter returns the first day on or after day on which a
+// month begins.
+func chineseNewMoonOnOrAfter(day int) int {
+	return chinaDay(newMoonAtOrAfter(midnightInChina(day)))
+}
+
+// chineseNewMoonBefore returns the last day before day on which a month
+// begins.
+func chineseNewMoonBefore(day int) int {
+	newMoon := chineseNewMoonOnOrAfter(day - 31)
+	for {
+		next := chineseNewMoonOnOrAfter(newMoon + 1)
+		if next >= day {
+			return newMoon
+		}
+		newMoon = next
+	}
+}
+
+// majorSolarTerm returns the major solar term in effect at the start of
+// day, 1 to 12, starting from Rain Water at 330°.
+func majorSolarTerm(day int) int {
+	s := solarLongitude(midnightInChina(day))
+	return (int(math.Floor(s/30))+2)%12 + 1
+}
+
+// noMajorSolarTerm reports whether the month beginning on day has no major
+// solar term, which makes it a leap month.
+func noMajorSolarTerm(day int) bool {
+	return majorSolarTerm(day) == majorSolarTerm(chineseNewMoonOnOrAfter(day+1))
+}
+
+// chineseWinterSolstice returns the day in Beijing of the December solstice
+// on or before day.
+func chineseWinterSolstice(day int) int {
+	// The solstice is the first day at whose end the longitude passes 270°,
+	// searching back from day.
+	solstice := day
+	for solarLongitude(midnightInChina(solstice+1)) < 270 || solarLongitude(midnightInChina(solstice+1)) > 300 {
+		solstice--
+	}
+	for solarLongitude(midnightInChina(solstice)) >= 270 && solarLongitude(midnightInChina(solstice)) < 300 {
+		solstice--
+	}
+	return solstice
+}
+
+// chineseNewYearInSui returns the new year of the solstice-to-solstice year
+// (sui) holding day: the second new moon after the first solstice, or the
+// third when a leap month falls between them.
+func chineseNewYearInSui(day int) int {
+	s1 := chineseWinterSolstice(day)
+	s2 := chineseWinterSolstice(s1 + 370)
+	m12 := chineseNewMoonOnOrAfter(s1 + 1)
+	m13 := chineseNewMoonOnOrAfter(m12 + 1)
+	nextM11 := chineseNewMoonBefore(s2 + 1)
+
+	if math.Round(float64(nextM11-m12)/synodicMonth) == 12 && (noMajorSolarTerm(m12) || noMajorSolarTerm(m13)) {
+		return chineseNewMoonOnOrAfter(m13 + 1)
+	}
+	return m13
+}
+
+// chineseLeapMonth reports whether the month beginning on newMoon is a leap
+// month: the first month with no major solar term in a sui of 13 months.
+func chineseLeapMonth(newMoon int) bool {
+	s1 := chineseWinterSolstice(newMoon)
+	s2 := chineseWinterSolstice(s1 + 370)
+	m12 := chineseNewMoonOnOrAfter(s1 + 1)
+	nextM11 := chineseNewMoonBefore(s2 + 1)
+	if math.Round(float64(nextM11-m12)/synodicMonth) != 12 || !noMajorSolarTerm(newMoon) {
+		return false
+	}
+	for month := chineseNewMoonBefore(newMoon); month >= m12; month = chineseNewMoonBefore(month) {
+		if noMajorSolarTerm(month) {
+			return false
+		}
+	}
+	return true
+}
+
+// ChineseDate returns the Gregorian date of a day of the Chinese year that
+// begins in the Gregorian year, e.g. ChineseDate(2024, 8, 15) for the
+// Mid-Autumn Festival. Months are the regular ones, never leap months. The
+// date is exact from 1900 to 2100 and approximate in other years.
+func ChineseDate(year, month, day int) time.Time {
+	newMoon := toFixed(LunarNewYear(year))
+	for n := 1; n < month; {
+		newMoon = chineseNewMoonOnOrAfter(newMoon + 1)
+		if !chineseLeapMonth(newMoon) {
+			n++
+		}
+	}
+	return fromFixed(newMoon + day - 1)
+}
+
+// Qingming returns the day of the Clear and Bright solar term, when the
+// Sun reaches 15° in Beijing, on April 4 or 5 in the years supported.
+func Qingming(year int) time.Time {
+	day := toFixed(time.Date(year, time.April, 1, 0, 0, 0, 0, time.UTC))
+	for solarLongitude(midnightInChina(day+1)) < 15 {
+		day++
+	}
+	return fromFixed(day)
+}
+
+// LunarNewYear returns the first day of the Chinese lunisolar year that
+// begins in the Gregorian year, between January 21 and February 20. The
+// date is exact from 1900 to 2100 and approximate in other years.
+func LunarNewYear(year int) time.Time {
+	july := toFixed(time.Date(year, time.July, 1, 0, 0, 0, 0, time.UTC))
+	newYear := chineseNewYearInSui(july)
+	if newYear > july {
+		newYear = chineseNewYearInSui(july - 180)
+	}
+	return fromFixed(newYear)
+}
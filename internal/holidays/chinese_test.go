@@ -0,0 +1,59 @@
+package holidays
+
+import "testing"
+
+func TestLunarNewYear(t *testing.T) {
+	expected := []string{
+		"1970-02-06", "1971-01-27", "1972-02-15", "1973-02-03", "1974-01-23",
+		"1975-02-11", "1976-01-31", "1977-02-18", "1978-02-07", "1979-01-28",
+		"1980-02-16", "1981-02-05", "1982-01-25", "1983-02-13", "1984-02-02",
+		"1985-02-20", "1986-02-09", "1987-01-29", "1988-02-17", "1989-02-06",
+		"1990-01-27", "1991-02-15", "1992-02-04", "1993-01-23", "1994-02-10",
+		"1995-01-31", "1996-02-19", "1997-02-07", "1998-01-28", "1999-02-16",
+		"2000-02-05", "2001-01-24", "2002-02-12", "2003-02-01", "2004-01-22",
+		"2005-02-09", "2006-01-29", "2007-02-18", "2008-02-07", "2009-01-26",
+		"2010-02-14", "2011-02-03", "2012-01-23", "2013-02-10", "2014-01-31",
+		"2015-02-19", "2016-02-08", "2017-01-28", "2018-02-16", "2019-02-05",
+		"2020-01-25", "2021-02-12", "2022-02-01", "2023-01-22", "2024-02-10",
+		"2025-01-29", "2026-02-17", "2027-02-06", "2028-01-26", "2029-02-13",
+		"2030-02-03",
+	}
+
+	for i, date := range expected {
+		year := 1970 + i
+		if got := LunarNewYear(year).Format("2006-01-02"); got != date {
+			t.Errorf("Lunar New Year %d: expected %s, got %s", year, date, got)
+		}
+	}
+}
+
+func TestLunarNewYearLeapMonths(t *testing.T) {
+	// Years following a leap month, including the disputed one of 2033.
+	tests := []struct {
+		year     int
+		expected string
+	}{
+		{1900, "1900-01-31"},
+		{1920, "1920-02-20"}, // the latest possible
+		{1929, "1929-02-10"}, // the first in UTC+8
+		{2034, "2034-02-19"},
+		{2057, "2057-02-04"},
+		{2099, "2099-01-21"}, // the earliest possible
+		{2100, "2100-02-09"},
+	}
+
+	for _, tt := range tests {
+		if got := LunarNewYear(tt.year).Format("2006-01-02"); got != tt.expected {
+			t.Errorf("Lunar New Year %d: expected %s, got %s", tt.year, tt.expected, got)
+		}
+	}
+}
+
+func TestLunarNewYearRange(t *testing.T) {
+	for year := 1900; year <= 2100; year++ {
+		newYear := LunarNewYear(year)
+		if newYear.Year() != year || newYear.YearDay() < 21 || newYear.YearDay() > 51 {
+			t.Errorf("Lunar New Year %d out of range: %s", year, newYear.Format("2006-01-02"))
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package holidays
+
+import (
+	"sort"
+	"time"
+)
+
+// Jurisdiction is a country, or a region of one, whose holidays are
+// combined with those of others.
+type Jurisdiction struct {
+	Code     string // ISO 3166 code with the region, if any, e.g. "DE-BY"
+	Region   string
+	Provider HolidayProvider
+}
+
+// CombinedProvider supplies the holidays of several jurisdictions together,
+// for work that crosses a border: by default a day is a holiday when it is
+// one in any of them, and with Intersect only when it is a day off in all.
+// Every holiday carries the code of its jurisdiction in Country.
+type CombinedProvider struct {
+	Jurisdictions []Jurisdiction
+	// Intersect keeps only the days off of every jurisdiction, the holidays
+	// of Types falling on the same date in all of them.
+	Intersect bool
+	// Types are the types of holiday that are days off for Intersect; zero
+	// means DefaultHolidayTypes.
+	Types HolidayTypes
+}
+
+// GetHolidays returns the holidays of every jurisdiction in date order.
+func (p *CombinedProvider) GetHolidays(year int) []Holiday {
+	lists := make([][]Holiday, len(p.Jurisdictions))
+	for i, jurisdiction := range p.Jurisdictions {
+		lists[i] = attribute(ForRegion(jurisdiction.Provider, year, jurisdiction.Region), jurisdiction.Code)
+	}
+
+	types := p.Types
+	if types == 0 {
+		types = DefaultHolidayTypes
+	}
+	keep := func(Holiday) bool { return true }
+	if p.Intersect {
+		keep = everywhere(lists, func(holiday Holiday) bool { return types.Has(holiday.Type) })
+	}
+	return merge(lists, keep)
+}
+
+// GetWorkingDays returns the special working days of the jurisdictions: a
+// weekend day is worked when it is a working day in every jurisdiction, or
+// with Intersect in any.
+func (p *CombinedProvider) GetWorkingDays(year int) []Holiday {
+	lists := make([][]Holiday, len(p.Jurisdictions))
+	for i, jurisdiction := range p.Jurisdictions {
+		lists[i] = attribute(WorkingDays(jurisdiction.Provider, year), jurisdiction.Code)
+	}
+
+	keep := func(Holiday) bool { return true }
+	if !p.Intersect {
+		keep = everywhere(lists, func(Holiday) bool { return true })
+	}
+	return merge(lists, keep)
+}
+
+// HasSchedule reports whether the schedules of every jurisdiction in year
+// are known.
+func (p *CombinedProvider) HasSchedule(year int) bool {
+	for _, jurisdiction := range p.Jurisdictions {
+		if !HasSchedule(jurisdiction.Provider, year) {
+			return false
+		}
+	}
+	return true
+}
+
+// attribute returns a copy of list with the days attributed to the
+// jurisdiction code.
+func attribute(list []Holiday, code string) []Holiday {
+	attributed := make([]Holiday, len(list))
+	for i, day := range list {
+		day.Country = code
+		attributed[i] = day
+	}
+	return attributed
+}
+
+// everywhere returns a filter keeping the days of lists that match in
+// every list.
+func everywhere(lists [][]Holiday, match func(Holiday) bool) func(Holiday) bool {
+	count := map[time.Time]int{}
+	for _, list := range lists {
+		seen := map[time.Time]bool{}
+		for _, day := range list {
+			if match(day) && !seen[day.Date] {
+				seen[day.Date] = true
+				count[day.Date]++
+			}
+		}
+	}
+	return func(day Holiday) bool {
+		return match(day) && count[day.Date] == len(lists)
+	}
+}
+
+// merge returns the days of lists that keep selects, in date order and,
+// on the same date, in the order of the lists.
+func merge(lists [][]Holiday, keep func(Holiday) bool) []Holiday {
+	var merged []Holiday
+	for _, list := range lists {
+		for _, day := range list {
+			if keep(day) {
+				merged = append(merged, day)
+			}
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Date.Before(merged[j].Date) })
+	return merged
+}
+
+// Intersection returns provider with Intersect set and the days off of
+// types when it is a *CombinedProvider; any other provider is returned as
+// it is, being the intersection of itself.
+func Intersection(provider HolidayProvider, types HolidayTypes) HolidayProvider {
+	combined, ok := provider.(*CombinedProvider)
+	if !ok {
+		return provider
+	}
+	intersection := *combined
+	intersection.Intersect = true
+	intersection.Types = types
+	return &intersection
+}
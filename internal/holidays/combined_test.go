@@ -0,0 +1,130 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func czechAndBavarian() *CombinedProvider {
+	return &CombinedProvider{Jurisdictions: []Jurisdiction{
+		{Code: "CZ", Provider: &CzechHolidayProvider{}},
+		{Code: "DE-BY", Region: "BY", Provider: &GermanHolidayProvider{}},
+	}}
+}
+
+func TestCombinedProviderUnion(t *testing.T) {
+	list := czechAndBavarian().GetHolidays(2024)
+
+	// 12 Czech holidays and 14 Bavarian ones, public, regional and optional.
+	if len(list) != 26 {
+		t.Errorf("Expected 26 holidays, got %d", len(list))
+	}
+	for i := 1; i < len(list); i++ {
+		if list[i].Date.Before(list[i-1].Date) {
+			t.Errorf("Holidays out of order: %s after %s", list[i].Date.Format("2006-01-02"), list[i-1].Date.Format("2006-01-02"))
+		}
+	}
+
+	countries := map[string]string{}
+	for _, holiday := range list {
+		countries[holiday.Date.Format("2006-01-02")] += holiday.Country + " "
+	}
+	tests := map[string]string{
+		"2024-05-01": "CZ DE-BY ",
+		"2024-05-08": "CZ ",
+		"2024-05-30": "DE-BY ",
+		"2024-10-03": "DE-BY ",
+		"2024-10-28": "CZ ",
+	}
+	for date, expected := range tests {
+		if countries[date] != expected {
+			t.Errorf("Holidays on %s in %q; want %q", date, countries[date], expected)
+		}
+	}
+}
+
+func TestCombinedProviderIntersection(t *testing.T) {
+	provider := czechAndBavarian()
+	provider.Intersect = true
+
+	var dates []string
+	for _, holiday := range provider.GetHolidays(2024) {
+		if holiday.Country == "CZ" {
+			dates = append(dates, holiday.Date.Format("2006-01-02"))
+		}
+	}
+	expected := []string{"2024-01-01", "2024-04-01", "2024-05-01", "2024-12-25", "2024-12-26"}
+	if len(dates) != len(expected) {
+		t.Fatalf("Shared holidays %v; want %v", dates, expected)
+	}
+	for i := range expected {
+		if dates[i] != expected[i] {
+			t.Errorf("Shared holidays %v; want %v", dates, expected)
+			break
+		}
+	}
+}
+
+func TestCombinedProviderIntersectionTypes(t *testing.T) {
+	provider := &CombinedProvider{Intersect: true, Jurisdictions: []Jurisdiction{
+		{Code: "DE-BY", Region: "BY", Provider: &GermanHolidayProvider{}},
+		{Code: "DE-BW", Region: "BW", Provider: &GermanHolidayProvider{}},
+	}}
+	epiphany := time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	if !IsHoliday(epiphany, provider.GetHolidays(2024)) {
+		t.Error("Epiphany is a regional holiday in both Bavaria and Baden-Württemberg")
+	}
+	provider.Types = TypesOf(Public)
+	if IsHoliday(epiphany, provider.GetHolidays(2024)) {
+		t.Error("Epiphany should not be shared when only public holidays are days off")
+	}
+}
+
+func TestCombinedProviderWorkingDays(t *testing.T) {
+	provider := &CombinedProvider{Jurisdictions: []Jurisdiction{
+		{Code: "CN", Provider: &ChineseHolidayProvider{}},
+		{Code: "CZ", Provider: &CzechHolidayProvider{}},
+	}}
+
+	// A weekend worked in China is still a weekend in Czechia.
+	if days := provider.GetWorkingDays(2024); len(days) != 0 {
+		t.Errorf("Union working days = %d; want none", len(days))
+	}
+
+	provider.Intersect = true
+	days := provider.GetWorkingDays(2024)
+	if len(days) != 8 || days[0].Country != "CN" || !days[0].Date.Equal(time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Intersection working days = %+v; want the 8 Chinese ones from 2024-02-04", days)
+	}
+}
+
+func TestCombinedProviderKeepsProviderLists(t *testing.T) {
+	provider := &CombinedProvider{Jurisdictions: []Jurisdiction{{Code: "XX", Provider: fixedProvider{}}}}
+	provider.GetHolidays(2024)
+	if fixedHolidays[0].Country != "" {
+		t.Error("CombinedProvider should not change the holidays of its providers")
+	}
+}
+
+var fixedHolidays = []Holiday{{Name: "Company Day", Date: time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)}}
+
+type fixedProvider struct{}
+
+func (fixedProvider) GetHolidays(int) []Holiday { return fixedHolidays }
+
+func TestIntersection(t *testing.T) {
+	czech := &CzechHolidayProvider{}
+	if Intersection(czech, 0) != HolidayProvider(czech) {
+		t.Error("Intersection() of a single country should return it unchanged")
+	}
+
+	union := czechAndBavarian()
+	intersection, ok := Intersection(union, TypesOf(Public)).(*CombinedProvider)
+	if !ok || !intersection.Intersect || intersection.Types != TypesOf(Public) {
+		t.Errorf("Intersection() = %+v; want an intersection of public holidays", intersection)
+	}
+	if union.Intersect {
+		t.Error("Intersection() should not change the provider it is given")
+	}
+}
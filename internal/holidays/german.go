@@ -0,0 +1,149 @@
+package holidays
+
+import (
+	"strings"
+	"time"
+)
+
+// germanLaender are the ISO 3166-2 codes of the German states, without the
+// "DE-" prefix.
+var germanLaender = []string{"BB", "BE", "BW", "BY", "HB", "HE", "HH", "MV", "NI", "NW", "RP", "SH", "SL", "SN", "ST", "TH"}
+
+// germanHoliday is a holiday kept by some of the Länder.
+type germanHoliday struct {
+	Holiday
+	date     func(year int) time.Time
+	laender  []string
+	since    int      // first year it is kept, 0 when always
+	optional []string // Länder where it is kept only in some municipalities
+}
+
+// GermanHolidayProvider supplies the holidays of Germany. Holidays are set
+// by the Länder; GetHolidays returns those kept in all of them and
+// GetRegionalHolidays adds the ones of a Land given by its ISO 3166-2 code,
+// e.g. "BY" or "DE-BY". Shops close on every holiday that is a day off.
+type GermanHolidayProvider struct{}
+
+func (p *GermanHolidayProvider) GetHolidays(year int) []Holiday {
+	easter := calculateEaster(year)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	holidays := []Holiday{
+		{ID: "new-years-day", Name: "Neujahr", EnglishName: "New Year's Day", Date: date(1, 1)},
+		{ID: "good-friday", Name: "Karfreitag", EnglishName: "Good Friday", Date: easter.AddDate(0, 0, -2)},
+		{ID: "easter-monday", Name: "Ostermontag", EnglishName: "Easter Monday", Date: easter.AddDate(0, 0, 1)},
+		{ID: "labour-day", Name: "Tag der Arbeit", EnglishName: "Labour Day", Date: date(5, 1)},
+		{ID: "ascension-day", Name: "Christi Himmelfahrt", EnglishName: "Ascension Day", Date: easter.AddDate(0, 0, 39)},
+		{ID: "whit-monday", Name: "Pfingstmontag", EnglishName: "Whit Monday", Date: easter.AddDate(0, 0, 50)},
+		{ID: "german-unity-day", Name: "Tag der Deutschen Einheit", EnglishName: "German Unity Day", Date: date(10, 3), Source: "Einigungsvertrag, Art. 2 Abs. 2"},
+		{ID: "christmas-day", Name: "1. Weihnachtstag", EnglishName: "Christmas Day", Date: date(12, 25)},
+		{ID: "second-day-of-christmas", Name: "2. Weihnachtstag", EnglishName: "Second Day of Christmas", Date: date(12, 26)},
+	}
+	if year == 2017 {
+		// The 500th anniversary of the Reformation was a holiday everywhere.
+		holidays = append(holidays, Holiday{ID: "reformation-day", Name: "Reformationstag", EnglishName: "Reformation Day", Date: date(10, 31)})
+	}
+
+	for i := range holidays {
+		holidays[i].Language = "de"
+		holidays[i].ShopsClosed = true
+		if holidays[i].Source == "" {
+			holidays[i].Source = "Feiertagsgesetze der Länder"
+		}
+	}
+	return holidays
+}
+
+func (p *GermanHolidayProvider) GetRegionalHolidays(year int, region string) []Holiday {
+	land := strings.TrimPrefix(strings.ToUpper(region), "DE-")
+	holidays := p.GetHolidays(year)
+
+	for _, regional := range germanRegionalHolidays {
+		if year < regional.since || year == 2017 && regional.ID == "reformation-day" {
+			continue
+		}
+
+		holiday := regional.Holiday
+		holiday.Date = regional.date(year)
+		holiday.Language = "de"
+		holiday.Source = "Feiertagsgesetz " + land
+		switch {
+		case contains(regional.laender, land):
+			holiday.Type = Regional
+			holiday.ShopsClosed = true
+		case contains(regional.optional, land):
+			holiday.Type = Optional
+		default:
+			continue
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays
+}
+
+// Regions returns the codes of the Länder.
+func (p *GermanHolidayProvider) Regions() []string {
+	return germanLaender
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func fixedDate(month time.Month, day int) func(int) time.Time {
+	return func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func afterEaster(days int) func(int) time.Time {
+	return func(year int) time.Time {
+		return calculateEaster(year).AddDate(0, 0, days)
+	}
+}
+
+// repentanceDay returns the Day of Repentance and Prayer, the Wednesday
+// before November 23.
+func repentanceDay(year int) time.Time {
+	day := time.Date(year, 11, 22, 0, 0, 0, 0, time.UTC)
+	for day.Weekday() != time.Wednesday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+var germanRegionalHolidays = []germanHoliday{
+	{Holiday: Holiday{ID: "epiphany", Name: "Heilige Drei Könige", EnglishName: "Epiphany"},
+		date: fixedDate(1, 6), laender: []string{"BW", "BY", "ST"}},
+	{Holiday: Holiday{ID: "womens-day", Name: "Internationaler Frauentag", EnglishName: "International Women's Day"},
+		date: fixedDate(3, 8), laender: []string{"BE"}, since: 2019},
+	{Holiday: Holiday{ID: "womens-day", Name: "Internationaler Frauentag", EnglishName: "International Women's Day"},
+		date: fixedDate(3, 8), laender: []string{"MV"}, since: 2023},
+	{Holiday: Holiday{ID: "easter-sunday", Name: "Ostersonntag", EnglishName: "Easter Sunday"},
+		date: afterEaster(0), laender: []string{"BB"}},
+	{Holiday: Holiday{ID: "whit-sunday", Name: "Pfingstsonntag", EnglishName: "Whit Sunday"},
+		date: afterEaster(49), laender: []string{"BB"}},
+	{Holiday: Holiday{ID: "corpus-christi", Name: "Fronleichnam", EnglishName: "Corpus Christi"},
+		date: afterEaster(60), laender: []string{"BW", "BY", "HE", "NW", "RP", "SL"}, optional: []string{"SN", "TH"}},
+	{Holiday: Holiday{ID: "augsburg-peace-festival", Name: "Augsburger Hohes Friedensfest", EnglishName: "Augsburg Peace Festival"},
+		date: fixedDate(8, 8), optional: []string{"BY"}},
+	{Holiday: Holiday{ID: "assumption-day", Name: "Mariä Himmelfahrt", EnglishName: "Assumption Day"},
+		date: fixedDate(8, 15), laender: []string{"SL"}, optional: []string{"BY"}},
+	{Holiday: Holiday{ID: "childrens-day", Name: "Weltkindertag", EnglishName: "World Children's Day"},
+		date: fixedDate(9, 20), laender: []string{"TH"}, since: 2019},
+	{Holiday: Holiday{ID: "reformation-day", Name: "Reformationstag", EnglishName: "Reformation Day"},
+		date: fixedDate(10, 31), laender: []string{"BB", "MV", "SN", "ST", "TH"}},
+	{Holiday: Holiday{ID: "reformation-day", Name: "Reformationstag", EnglishName: "Reformation Day"},
+		date: fixedDate(10, 31), laender: []string{"HB", "HH", "NI", "SH"}, since: 2018},
+	{Holiday: Holiday{ID: "all-saints-day", Name: "Allerheiligen", EnglishName: "All Saints' Day"},
+		date: fixedDate(11, 1), laender: []string{"BW", "BY", "NW", "RP", "SL"}},
+	{Holiday: Holiday{ID: "repentance-day", Name: "Buß- und Bettag", EnglishName: "Day of Repentance and Prayer"},
+		date: repentanceDay, laender: []string{"SN"}},
+}
@@ -0,0 +1,122 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGermanHolidayProvider(t *testing.T) {
+	provider := &GermanHolidayProvider{}
+
+	tests := []struct {
+		year     int
+		expected int
+	}{
+		{2024, 9},
+		{2017, 10}, // Reformation Day nationwide
+	}
+
+	for _, tt := range tests {
+		holidays := provider.GetHolidays(tt.year)
+		if len(holidays) != tt.expected {
+			t.Errorf("GetHolidays(%d) returned %d holidays; want %d", tt.year, len(holidays), tt.expected)
+		}
+		for _, holiday := range holidays {
+			if holiday.Type != Public || holiday.Language != "de" || !holiday.ShopsClosed || holiday.EnglishName == "" {
+				t.Errorf("%s has unexpected metadata: %+v", holiday.Name, holiday)
+			}
+		}
+	}
+}
+
+func TestGermanRegionalHolidays(t *testing.T) {
+	provider := &GermanHolidayProvider{}
+
+	tests := []struct {
+		name     string
+		year     int
+		region   string
+		id       string
+		date     string
+		expected HolidayType
+		found    bool
+	}{
+		{"Epiphany in Bavaria", 2024, "BY", "epiphany", "2024-01-06", Regional, true},
+		{"Epiphany not in Berlin", 2024, "BE", "epiphany", "", 0, false},
+		{"Women's Day in Berlin", 2024, "BE", "womens-day", "2024-03-08", Regional, true},
+		{"Women's Day in Berlin before 2019", 2018, "BE", "womens-day", "", 0, false},
+		{"Corpus Christi in Hesse", 2024, "DE-HE", "corpus-christi", "2024-05-30", Regional, true},
+		{"Corpus Christi in Saxony", 2024, "sn", "corpus-christi", "2024-05-30", Optional, true},
+		{"Assumption Day in Bavaria", 2024, "BY", "assumption-day", "2024-08-15", Optional, true},
+		{"Assumption Day in Saarland", 2024, "SL", "assumption-day", "2024-08-15", Regional, true},
+		{"Reformation Day in Hamburg", 2024, "HH", "reformation-day", "2024-10-31", Regional, true},
+		{"Reformation Day in Hamburg before 2018", 2016, "HH", "reformation-day", "", 0, false},
+		{"Day of Repentance in Saxony", 2024, "SN", "repentance-day", "2024-11-20", Regional, true},
+		{"Day of Repentance on November 22", 2023, "SN", "repentance-day", "2023-11-22", Regional, true},
+		{"Whit Sunday in Brandenburg", 2024, "BB", "whit-sunday", "2024-05-19", Regional, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var found *Holiday
+			holidays := provider.GetRegionalHolidays(tt.year, tt.region)
+			for i := range holidays {
+				if holidays[i].ID == tt.id {
+					found = &holidays[i]
+				}
+			}
+			if found == nil {
+				if tt.found {
+					t.Fatalf("%s not found in %s %d", tt.id, tt.region, tt.year)
+				}
+				return
+			}
+			if !tt.found {
+				t.Fatalf("%s unexpectedly found in %s %d", tt.id, tt.region, tt.year)
+			}
+
+			expected, _ := time.Parse("2006-01-02", tt.date)
+			if !found.Date.Equal(expected) || found.Type != tt.expected {
+				t.Errorf("%s = %s, %s; want %s, %s", tt.id, found.Date.Format("2006-01-02"), found.Type, tt.date, tt.expected)
+			}
+			if found.ShopsClosed != (tt.expected == Regional) {
+				t.Errorf("%s ShopsClosed = %v", tt.id, found.ShopsClosed)
+			}
+		})
+	}
+}
+
+func TestGermanReformationDay2017(t *testing.T) {
+	count := 0
+	for _, holiday := range (&GermanHolidayProvider{}).GetRegionalHolidays(2017, "SN") {
+		if holiday.ID == "reformation-day" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Reformation Day 2017 in Saxony listed %d times; want once", count)
+	}
+}
+
+func TestKnownRegion(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider HolidayProvider
+		region   string
+		expected bool
+	}{
+		{"Provider without regions", &CzechHolidayProvider{}, "PHA", false},
+		{"Provider that does not list regions", &regionalProvider{}, "anywhere", true},
+		{"Listed region", &GermanHolidayProvider{}, "by", true},
+		{"Listed region with country", &GermanHolidayProvider{}, "DE-BY", true},
+		{"Unknown region", &GermanHolidayProvider{}, "XX", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := KnownRegion(tt.provider, tt.region); result != tt.expected {
+				t.Errorf("KnownRegion(%q) = %v; want %v", tt.region, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package holidays
+
+import "time"
+
+// GreekHolidayProvider supplies the days off of Greece, with Clean Monday,
+// Easter and Whit Monday kept by the Orthodox calendar. Whit Monday is a day
+// off for the public sector and banks only.
+type GreekHolidayProvider struct{}
+
+func (p *GreekHolidayProvider) GetHolidays(year int) []Holiday {
+	easter := calculateOrthodoxEaster(year)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	holidays := []Holiday{
+		{ID: "new-years-day", Name: "Πρωτοχρονιά", EnglishName: "New Year's Day", Date: date(1, 1)},
+		{ID: "epiphany", Name: "Θεοφάνεια", EnglishName: "Epiphany", Date: date(1, 6)},
+		{ID: "clean-monday", Name: "Καθαρά Δευτέρα", EnglishName: "Clean Monday", Date: easter.AddDate(0, 0, -48)},
+		{ID: "independence-day", Name: "Ευαγγελισμός της Θεοτόκου και Εθνική Εορτή", EnglishName: "Independence Day", Date: date(3, 25)},
+		{ID: "good-friday", Name: "Μεγάλη Παρασκευή", EnglishName: "Orthodox Good Friday", Date: easter.AddDate(0, 0, -2)},
+		{ID: "easter-sunday", Name: "Κυριακή του Πάσχα", EnglishName: "Orthodox Easter Sunday", Date: easter},
+		{ID: "easter-monday", Name: "Δευτέρα του Πάσχα", EnglishName: "Orthodox Easter Monday", Date: easter.AddDate(0, 0, 1)},
+		{ID: "labour-day", Name: "Πρωτομαγιά", EnglishName: "Labour Day", Date: date(5, 1)},
+		{ID: "whit-monday", Name: "Δευτέρα του Αγίου Πνεύματος", EnglishName: "Orthodox Whit Monday", Date: easter.AddDate(0, 0, 50), Type: Bank},
+		{ID: "assumption-day", Name: "Κοίμηση της Θεοτόκου", EnglishName: "Assumption Day", Date: date(8, 15)},
+		{ID: "ochi-day", Name: "Επέτειος του Όχι", EnglishName: "Ochi Day", Date: date(10, 28)},
+		{ID: "christmas-day", Name: "Χριστούγεννα", EnglishName: "Christmas Day", Date: date(12, 25)},
+		{ID: "second-day-of-christmas", Name: "Σύναξη της Υπεραγίας Θεοτόκου", EnglishName: "Second Day of Christmas", Date: date(12, 26)},
+	}
+
+	for i := range holidays {
+		holidays[i].Language = "el"
+	}
+	return holidays
+}
@@ -0,0 +1,29 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGreekHolidayProvider(t *testing.T) {
+	holidays := (&GreekHolidayProvider{}).GetHolidays(2024)
+	if len(holidays) != 13 {
+		t.Errorf("Expected 13 Greek holidays, got %d", len(holidays))
+	}
+
+	expected := map[string]string{
+		"clean-monday":  "2024-03-18",
+		"good-friday":   "2024-05-03",
+		"easter-monday": "2024-05-06",
+		"whit-monday":   "2024-06-24",
+		"ochi-day":      "2024-10-28",
+	}
+	for _, holiday := range holidays {
+		if date, ok := expected[holiday.ID]; ok && holiday.Date.Format(time.DateOnly) != date {
+			t.Errorf("%s on %s; want %s", holiday.ID, holiday.Date.Format(time.DateOnly), date)
+		}
+		if (holiday.ID == "whit-monday") != (holiday.Type == Bank) {
+			t.Errorf("%s has type %s", holiday.ID, holiday.Type)
+		}
+	}
+}
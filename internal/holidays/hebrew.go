@@ -0,0 +1,117 @@
+package holidays
+
+import "time"
+
+// Dates of the calendars below are counted in fixed days, the Rata Die of
+// Reingold and Dershowitz's "Calendrical Calculations": day 1 is January 1
+// of year 1 in the proleptic Gregorian calendar.
+const unixEpochFixed = 719163 // 1970-01-01
+
+func fromFixed(day int) time.Time {
+	return time.Unix(int64(day-unixEpochFixed)*86400, 0).UTC()
+}
+
+func toFixed(date time.Time) int {
+	return int(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Unix()/86400) + unixEpochFixed
+}
+
+// Months of the Hebrew calendar, numbered from Nisan as in the Bible. The
+// year begins with Tishri; Adar II exists only in leap years, when Adar is
+// Adar I.
+const (
+	Nisan      = 1
+	Iyyar      = 2
+	Sivan      = 3
+	Tammuz     = 4
+	Av         = 5
+	Elul       = 6
+	Tishri     = 7
+	Marheshvan = 8
+	Kislev     = 9
+	Tevet      = 10
+	Shevat     = 11
+	Adar       = 12
+	AdarII     = 13
+)
+
+const hebrewEpoch = -1373427 // Tishri 1, 1 AM: October 7, 3761 BCE (Julian)
+
+// hebrewLeapYear reports whether the year has Adar II, seven years in every
+// 19-year cycle.
+func hebrewLeapYear(year int) bool {
+	return (7*year+1)%19 < 7
+}
+
+// hebrewElapsedDays is the number of days from the epoch to the molad of
+// Tishri of year, postponed when the molad falls on a Sunday, Wednesday or
+// Friday.
+func hebrewElapsedDays(year int) int {
+	monthsElapsed := (235*year - 234) / 19
+	partsElapsed := 12084 + 13753*monthsElapsed
+	days := 29*monthsElapsed + partsElapsed/25920
+	if (3*(days+1))%7 < 3 {
+		return days + 1
+	}
+	return days
+}
+
+// hebrewNewYear is the fixed day of Tishri 1 of year, after the
+// postponements that keep the year 353-355 or 383-385 days long.
+func hebrewNewYear(year int) int {
+	previous, current, next := hebrewElapsedDays(year-1), hebrewElapsedDays(year), hebrewElapsedDays(year+1)
+	correction := 0
+	switch {
+	case next-current == 356:
+		correction = 2
+	case current-previous == 382:
+		correction = 1
+	}
+	return hebrewEpoch + current + correction
+}
+
+func hebrewMonthDays(year, month int) int {
+	yearDays := hebrewNewYear(year+1) - hebrewNewYear(year)
+	switch {
+	case month == Iyyar, month == Tammuz, month == Elul, month == Tevet, month == AdarII:
+		return 29
+	case month == Adar && !hebrewLeapYear(year):
+		return 29
+	case month == Marheshvan && yearDays%10 != 5: // only 355 and 385 day years have a long Marheshvan
+		return 29
+	case month == Kislev && yearDays%10 == 3: // 353 and 383 day years have a short Kislev
+		return 29
+	}
+	return 30
+}
+
+// HebrewDate returns the Gregorian date of a day of the Hebrew calendar,
+// e.g. HebrewDate(5785, Tishri, 10) for Yom Kippur 2024. Years are counted
+// from the creation (anno mundi); Gregorian year y holds the Tishri of
+// Hebrew year y+3761.
+func HebrewDate(year, month, day int) time.Time {
+	lastMonth := Adar
+	if hebrewLeapYear(year) {
+		lastMonth = AdarII
+	}
+
+	fixed := hebrewNewYear(year) + day - 1
+	if month < Tishri {
+		for m := Tishri; m <= lastMonth; m++ {
+			fixed += hebrewMonthDays(year, m)
+		}
+		for m := Nisan; m < month; m++ {
+			fixed += hebrewMonthDays(year, m)
+		}
+	} else {
+		for m := Tishri; m < month; m++ {
+			fixed += hebrewMonthDays(year, m)
+		}
+	}
+	return fromFixed(fixed)
+}
+
+// RoshHashanah returns the first day of the Jewish New Year in the
+// Gregorian year; the holiday starts at sunset the evening before.
+func RoshHashanah(year int) time.Time {
+	return HebrewDate(year+3761, Tishri, 1)
+}
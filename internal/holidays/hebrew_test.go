@@ -0,0 +1,78 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoshHashanah(t *testing.T) {
+	expected := []string{
+		"2000-09-30", "2001-09-18", "2002-09-07", "2003-09-27", "2004-09-16",
+		"2005-10-04", "2006-09-23", "2007-09-13", "2008-09-30", "2009-09-19",
+		"2010-09-09", "2011-09-29", "2012-09-17", "2013-09-05", "2014-09-25",
+		"2015-09-14", "2016-10-03", "2017-09-21", "2018-09-10", "2019-09-30",
+		"2020-09-19", "2021-09-07", "2022-09-26", "2023-09-16", "2024-10-03",
+		"2025-09-23", "2026-09-12",
+	}
+
+	for i, date := range expected {
+		year := 2000 + i
+		if got := RoshHashanah(year).Format("2006-01-02"); got != date {
+			t.Errorf("Rosh Hashanah %d: expected %s, got %s", year, date, got)
+		}
+	}
+}
+
+func TestHebrewDate(t *testing.T) {
+	tests := []struct {
+		year, month, day int
+		expected         string
+	}{
+		{5781, Nisan, 15, "2021-03-28"}, // Passover
+		{5782, Nisan, 15, "2022-04-16"},
+		{5783, Nisan, 15, "2023-04-06"},
+		{5784, Nisan, 15, "2024-04-23"}, // after Adar II
+		{5785, Nisan, 15, "2025-04-13"},
+		{5784, Tishri, 10, "2023-09-25"}, // Yom Kippur
+		{5785, Tishri, 10, "2024-10-12"},
+		{5784, Adar, 14, "2024-02-23"},   // Purim Katan in Adar I
+		{5784, AdarII, 14, "2024-03-24"}, // Purim
+		{5785, Kislev, 25, "2024-12-26"}, // Hanukkah
+	}
+
+	for _, tt := range tests {
+		if got := HebrewDate(tt.year, tt.month, tt.day).Format("2006-01-02"); got != tt.expected {
+			t.Errorf("HebrewDate(%d, %d, %d): expected %s, got %s", tt.year, tt.month, tt.day, tt.expected, got)
+		}
+	}
+}
+
+func TestHebrewYearLength(t *testing.T) {
+	// A year has 353-355 days, a leap year 383-385, and its months add up.
+	for year := 5600; year <= 6000; year++ {
+		length := hebrewNewYear(year+1) - hebrewNewYear(year)
+		lastMonth := Adar
+		valid := length >= 353 && length <= 355
+		if hebrewLeapYear(year) {
+			lastMonth = AdarII
+			valid = length >= 383 && length <= 385
+		}
+		if !valid {
+			t.Errorf("Hebrew year %d has %d days", year, length)
+		}
+
+		days := 0
+		for month := Nisan; month <= lastMonth; month++ {
+			days += hebrewMonthDays(year, month)
+		}
+		if days != length {
+			t.Errorf("months of Hebrew year %d add up to %d days; want %d", year, days, length)
+		}
+
+		// Rosh Hashanah never falls on a Sunday, Wednesday or Friday.
+		switch fromFixed(hebrewNewYear(year)).Weekday() {
+		case time.Sunday, time.Wednesday, time.Friday:
+			t.Errorf("Rosh Hashanah %d falls on a %s", year, fromFixed(hebrewNewYear(year)).Weekday())
+		}
+	}
+}
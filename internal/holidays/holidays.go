@@ -1,18 +1,120 @@
 package holidays
 
 import (
+	"sort"
+	"strings"
 	"time"
 )
 
 type Holiday struct {
-	Name string
-	Date time.Time
+	ID          string // stable slug, e.g. "new-years-day"
+	Name        string // in the country's language
+	EnglishName string
+	Language    string // ISO 639-1 code of Name, e.g. "cs"
+	Date        time.Time
+	Type        HolidayType
+	ShopsClosed bool   // retail stores must close for the whole day
+	Source      string // the law that establishes the holiday
+	Country     string // jurisdiction of a combined calendar, e.g. "DE-BY"
+}
+
+// NameIn returns the name of the holiday in the language with the given
+// ISO 639-1 code: the local name in its own language, the English name in
+// any other, and the local name when there is no English one.
+func (h Holiday) NameIn(lang string) string {
+	if lang == h.Language || h.EnglishName == "" {
+		return h.Name
+	}
+	return h.EnglishName
 }
 
 type HolidayProvider interface {
 	GetHolidays(year int) []Holiday
 }
 
+// RegionalProvider is implemented by providers whose holidays differ
+// between regions of the country.
+type RegionalProvider interface {
+	HolidayProvider
+	// GetRegionalHolidays returns the nationwide holidays and those of
+	// region.
+	GetRegionalHolidays(year int, region string) []Holiday
+}
+
+// WorkingDayProvider is implemented by providers of countries that declare
+// weekend days working days, usually to make up for a weekday given off to
+// bridge a holiday and a weekend.
+type WorkingDayProvider interface {
+	HolidayProvider
+	// GetWorkingDays returns the special working days of year, named after
+	// the holiday they make up for.
+	GetWorkingDays(year int) []Holiday
+}
+
+// WorkingDays returns the special working days of provider in year, none
+// for a provider that has no such days.
+func WorkingDays(provider HolidayProvider, year int) []Holiday {
+	if working, ok := provider.(WorkingDayProvider); ok {
+		return working.GetWorkingDays(year)
+	}
+	return nil
+}
+
+// ScheduledProvider is implemented by providers of countries whose days off
+// follow a schedule published every year, as China's do. Years without a
+// known schedule have the statutory holidays only.
+type ScheduledProvider interface {
+	HolidayProvider
+	// HasSchedule reports whether the schedule of year is known.
+	HasSchedule(year int) bool
+}
+
+// HasSchedule reports whether the days off of provider in year are known in
+// full: always for a provider without schedules.
+func HasSchedule(provider HolidayProvider, year int) bool {
+	if scheduled, ok := provider.(ScheduledProvider); ok {
+		return scheduled.HasSchedule(year)
+	}
+	return true
+}
+
+// RegionLister is implemented by regional providers that know all their
+// regions, so that an unknown region can be reported.
+type RegionLister interface {
+	Regions() []string
+}
+
+// KnownRegion reports whether provider has holidays for region: never for
+// a provider without regions, always for one that does not list them. The
+// region may carry its country prefix, as in "DE-BY".
+func KnownRegion(provider HolidayProvider, region string) bool {
+	if _, ok := provider.(RegionalProvider); !ok {
+		return false
+	}
+	lister, ok := provider.(RegionLister)
+	if !ok {
+		return true
+	}
+	if country, subdivision, ok := strings.Cut(region, "-"); ok && len(country) == 2 {
+		region = subdivision
+	}
+	for _, known := range lister.Regions() {
+		if strings.EqualFold(known, region) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForRegion returns the holidays of provider in year, narrowed to region
+// when it is given and the provider knows regions.
+func ForRegion(provider HolidayProvider, year int, region string) []Holiday {
+	if regional, ok := provider.(RegionalProvider); ok && region != "" {
+		return regional.GetRegionalHolidays(year, region)
+	}
+	return provider.GetHolidays(year)
+}
+
 // calculateEaster computes the date of Easter Sunday for a given year
 // using the Gregorian calendar algorithm (Anonymous Gregorian algorithm).
 //
@@ -54,38 +156,131 @@ func calculateEaster(year int) time.Time {
 	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
 }
 
+// calculateOrthodoxEaster computes the date of Easter Sunday kept by the
+// Orthodox churches, which follow the Julian calendar: Meeus's Julian
+// algorithm gives the Julian date, and adding the days the Julian calendar
+// lags behind turns it into a Gregorian one.
+//
+// The lag grows by a day in every century year not divisible by 400, from
+// 10 days in 1583 to 13 days in 1900-2099. Easter always falls after the
+// Julian leap day, so the lag of the whole year applies.
+func calculateOrthodoxEaster(year int) time.Time {
+	goldenNumber := year % 19
+	d := (19*goldenNumber + 15) % 30
+	e := (2*(year%4) + 4*(year%7) - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+
+	julianLag := year/100 - year/400 - 2
+	return time.Date(year, time.Month(month), day+julianLag, 0, 0, 0, 0, time.UTC)
+}
+
+// substituteDays returns a day off on the first following working day for
+// each holiday of list that falls on a day of weekend and is moved by law.
+// Substitutes keep the holiday's ID and names, marked with suffix in the
+// local language.
+func substituteDays(list []Holiday, weekend func(time.Weekday) bool, moved func(Holiday) bool, suffix string) []Holiday {
+	taken := map[time.Time]bool{}
+	for _, holiday := range list {
+		taken[holiday.Date] = true
+	}
+	list = append([]Holiday(nil), list...)
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Date.Before(list[j].Date) })
+
+	var substitutes []Holiday
+	for _, holiday := range list {
+		if !weekend(holiday.Date.Weekday()) || !moved(holiday) {
+			continue
+		}
+		day := holiday.Date.AddDate(0, 0, 1)
+		for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday || taken[day] {
+			day = day.AddDate(0, 0, 1)
+		}
+		taken[day] = true
+
+		substitute := holiday
+		substitute.ID += "-substitute"
+		substitute.Name += " (" + suffix + ")"
+		if substitute.EnglishName != "" {
+			substitute.EnglishName += " (substitute day)"
+		}
+		substitute.Date = day
+		substitutes = append(substitutes, substitute)
+	}
+	return substitutes
+}
+
+// Sources of the Czech holidays: the holidays act and, for the closing of
+// shops, the retail opening hours act (zákon č. 223/2016 Sb.), which also
+// closes shops from noon on Christmas Eve.
+const (
+	czechStateHoliday = "zákon č. 245/2000 Sb., § 1" // státní svátky
+	czechOtherHoliday = "zákon č. 245/2000 Sb., § 2" // ostatní svátky
+)
+
 type CzechHolidayProvider struct{}
 
 func (p *CzechHolidayProvider) GetHolidays(year int) []Holiday {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
 	holidays := []Holiday{
-		{Name: "Nový rok", Date: time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)},
-		{Name: "Svátek práce", Date: time.Date(year, 5, 1, 0, 0, 0, 0, time.UTC)},
-		{Name: "Den vítězství", Date: time.Date(year, 5, 8, 0, 0, 0, 0, time.UTC)},
-		{Name: "Den slovanských věrozvěstů Cyrila a Metoděje", Date: time.Date(year, 7, 5, 0, 0, 0, 0, time.UTC)},
-		{Name: "Den upálení mistra Jana Husa", Date: time.Date(year, 7, 6, 0, 0, 0, 0, time.UTC)},
-		{Name: "Den české státnosti", Date: time.Date(year, 9, 28, 0, 0, 0, 0, time.UTC)},
-		{Name: "Den vzniku samostatného československého státu", Date: time.Date(year, 10, 28, 0, 0, 0, 0, time.UTC)},
-		{Name: "Den boje za svobodu a demokracii", Date: time.Date(year, 11, 17, 0, 0, 0, 0, time.UTC)},
-		{Name: "Štědrý den", Date: time.Date(year, 12, 24, 0, 0, 0, 0, time.UTC)},
-		{Name: "1. svátek vánoční", Date: time.Date(year, 12, 25, 0, 0, 0, 0, time.UTC)},
-		{Name: "2. svátek vánoční", Date: time.Date(year, 12, 26, 0, 0, 0, 0, time.UTC)},
+		{ID: "new-years-day", Name: "Nový rok", EnglishName: "New Year's Day", Date: date(1, 1), ShopsClosed: true, Source: czechOtherHoliday},
+		{ID: "labour-day", Name: "Svátek práce", EnglishName: "Labour Day", Date: date(5, 1), Source: czechOtherHoliday},
+		{ID: "liberation-day", Name: "Den vítězství", EnglishName: "Liberation Day", Date: date(5, 8), ShopsClosed: true, Source: czechStateHoliday},
+		{ID: "cyril-methodius-day", Name: "Den slovanských věrozvěstů Cyrila a Metoděje", EnglishName: "St. Cyril and Methodius Day", Date: date(7, 5), Source: czechStateHoliday},
+		{ID: "jan-hus-day", Name: "Den upálení mistra Jana Husa", EnglishName: "Jan Hus Day", Date: date(7, 6), Source: czechStateHoliday},
+		{ID: "statehood-day", Name: "Den české státnosti", EnglishName: "Czech Statehood Day", Date: date(9, 28), ShopsClosed: true, Source: czechStateHoliday},
+		{ID: "independence-day", Name: "Den vzniku samostatného československého státu", EnglishName: "Independence Day", Date: date(10, 28), ShopsClosed: true, Source: czechStateHoliday},
+		{ID: "freedom-day", Name: "Den boje za svobodu a demokracii", EnglishName: "Freedom Day", Date: date(11, 17), Source: czechStateHoliday},
+		{ID: "christmas-eve", Name: "Štědrý den", EnglishName: "Christmas Eve", Date: date(12, 24), Source: czechOtherHoliday},
+		{ID: "christmas-day", Name: "1. svátek vánoční", EnglishName: "Christmas Day", Date: date(12, 25), ShopsClosed: true, Source: czechOtherHoliday},
+		{ID: "st-stephens-day", Name: "2. svátek vánoční", EnglishName: "St. Stephen's Day", Date: date(12, 26), ShopsClosed: true, Source: czechOtherHoliday},
 	}
 
 	holidays = append(holidays, p.getEasterMonday(year))
 
+	for i := range holidays {
+		holidays[i].Language = "cs"
+	}
 	return holidays
 }
 
 func (p *CzechHolidayProvider) getEasterMonday(year int) Holiday {
 	easter := calculateEaster(year)
 	easterMonday := easter.AddDate(0, 0, 1)
-	return Holiday{Name: "Velikonoční pondělí", Date: easterMonday}
+	return Holiday{ID: "easter-monday", Name: "Velikonoční pondělí", EnglishName: "Easter Monday", Date: easterMonday, ShopsClosed: true, Source: czechOtherHoliday}
 }
 
 func GetProvider(country string) HolidayProvider {
 	return &CzechHolidayProvider{}
 }
 
+// Lookup returns the holiday provider for an ISO 3166-1 country code, in
+// any letter case.
+func Lookup(country string) (HolidayProvider, bool) {
+	switch strings.ToUpper(country) {
+	case "CZ":
+		return &CzechHolidayProvider{}, true
+	case "BG":
+		return &BulgarianHolidayProvider{}, true
+	case "CN":
+		return &ChineseHolidayProvider{}, true
+	case "DE":
+		return &GermanHolidayProvider{}, true
+	case "GR":
+		return &GreekHolidayProvider{}, true
+	case "RO":
+		return &RomanianHolidayProvider{}, true
+	case "RS":
+		return &SerbianHolidayProvider{}, true
+	case "UA":
+		return &UkrainianHolidayProvider{}, true
+	}
+	return nil, false
+}
+
 func IsHoliday(date time.Time, holidays []Holiday) bool {
 	for _, holiday := range holidays {
 		if holiday.Date.Year() == date.Year() &&
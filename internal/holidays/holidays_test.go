@@ -84,3 +84,157 @@ func TestEasterCalculation(t *testing.T) {
 		}
 	}
 }
+
+func TestOrthodoxEasterCalculation(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected string
+	}{
+		{1583, "1583-04-10"}, // 10 days behind
+		{1700, "1700-04-11"}, // 11 days
+		{1800, "1800-04-20"}, // 12 days
+		{1900, "1900-04-22"}, // 13 days
+		{2000, "2000-04-30"},
+		{2021, "2021-05-02"},
+		{2022, "2022-04-24"},
+		{2024, "2024-05-05"},
+		{2025, "2025-04-20"}, // same as Western Easter
+		{2100, "2100-05-02"}, // 14 days
+		{2200, "2200-04-06"}, // 15 days
+		{4099, "4099-05-03"},
+	}
+
+	for _, tt := range tests {
+		easter := calculateOrthodoxEaster(tt.year)
+		expected, _ := time.Parse("2006-01-02", tt.expected)
+		if !easter.Equal(expected) {
+			t.Errorf("Orthodox Easter %d: expected %s, got %s", tt.year, tt.expected, easter.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestOrthodoxEasterRange(t *testing.T) {
+	for year := 1583; year <= 4099; year++ {
+		easter := calculateOrthodoxEaster(year)
+		western := calculateEaster(year)
+		if easter.Weekday() != time.Sunday || easter.Before(western) || easter.Year() != year {
+			t.Fatalf("Orthodox Easter %d on %s is not a Sunday on or after %s", year, easter.Format("2006-01-02 Mon"), western.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestSubstituteDays(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2021, 12, d, 0, 0, 0, 0, time.UTC) }
+	list := []Holiday{
+		{ID: "second", Name: "Second", EnglishName: "Second Day", Date: day(26)}, // Sunday
+		{ID: "first", Name: "First", Date: day(25)},                              // Saturday
+		{ID: "kept", Name: "Kept", Date: day(19)},                                // Sunday, not moved
+		{ID: "monday", Name: "Monday", Date: day(27)},
+	}
+	weekend := func(day time.Weekday) bool { return day == time.Saturday || day == time.Sunday }
+	moved := func(holiday Holiday) bool { return holiday.ID != "kept" }
+
+	substitutes := substituteDays(list, weekend, moved, "off")
+	if len(substitutes) != 2 {
+		t.Fatalf("substituteDays() returned %d days; want 2", len(substitutes))
+	}
+	if substitutes[0].ID != "first-substitute" || !substitutes[0].Date.Equal(day(28)) || substitutes[0].Name != "First (off)" {
+		t.Errorf("first substitute = %+v; want First (off) on December 28", substitutes[0])
+	}
+	if !substitutes[1].Date.Equal(day(29)) || substitutes[1].EnglishName != "Second Day (substitute day)" {
+		t.Errorf("second substitute = %+v; want Second Day (substitute day) on December 29", substitutes[1])
+	}
+}
+
+type regionalProvider struct{ CzechHolidayProvider }
+
+func (p *regionalProvider) GetRegionalHolidays(year int, region string) []Holiday {
+	return append(p.GetHolidays(year), Holiday{Name: region, Date: time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC)})
+}
+
+func TestForRegion(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider HolidayProvider
+		region   string
+		expected int
+	}{
+		{"Provider without regions", &CzechHolidayProvider{}, "prague", 12},
+		{"Regional provider", &regionalProvider{}, "prague", 13},
+		{"Regional provider, no region", &regionalProvider{}, "", 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(ForRegion(tt.provider, 2024, tt.region)); got != tt.expected {
+				t.Errorf("ForRegion() returned %d holidays; want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCzechHolidayEnglishNames(t *testing.T) {
+	for _, holiday := range (&CzechHolidayProvider{}).GetHolidays(2024) {
+		if holiday.EnglishName == "" {
+			t.Errorf("%s has no English name", holiday.Name)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, country := range []string{"CZ", "cz", "DE", "RO", "BG", "GR", "RS", "UA"} {
+		if _, ok := Lookup(country); !ok {
+			t.Errorf("Lookup(%q) found no provider", country)
+		}
+	}
+	if _, ok := Lookup("XX"); ok {
+		t.Error("Lookup(XX) should find no provider")
+	}
+}
+
+func TestCzechHolidayMetadata(t *testing.T) {
+	provider := &CzechHolidayProvider{}
+	holidays := provider.GetHolidays(2024)
+
+	ids := map[string]bool{}
+	shopsClosed := 0
+	for _, holiday := range holidays {
+		if holiday.ID == "" || ids[holiday.ID] {
+			t.Errorf("%s has a missing or duplicate ID %q", holiday.Name, holiday.ID)
+		}
+		ids[holiday.ID] = true
+		if holiday.EnglishName == "" || holiday.Language != "cs" || holiday.Type != Public || holiday.Source == "" {
+			t.Errorf("%s has incomplete metadata: %+v", holiday.Name, holiday)
+		}
+		if holiday.ShopsClosed {
+			shopsClosed++
+		}
+	}
+
+	// Shops close on 7 holidays by zákon č. 223/2016 Sb.
+	if shopsClosed != 7 {
+		t.Errorf("Expected shops to close on 7 holidays, got %d", shopsClosed)
+	}
+}
+
+func TestNameIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		holiday  Holiday
+		lang     string
+		expected string
+	}{
+		{"Own language", Holiday{Name: "Nový rok", EnglishName: "New Year's Day", Language: "cs"}, "cs", "Nový rok"},
+		{"English", Holiday{Name: "Nový rok", EnglishName: "New Year's Day", Language: "cs"}, "en", "New Year's Day"},
+		{"Other language", Holiday{Name: "Neujahr", EnglishName: "New Year's Day", Language: "de"}, "cs", "New Year's Day"},
+		{"No English name", Holiday{Name: "Company day"}, "en", "Company day"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.holiday.NameIn(tt.lang); result != tt.expected {
+				t.Errorf("NameIn(%q) = %q, want %q", tt.lang, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package holidays
+
+import "time"
+
+// Months of the Islamic calendar.
+const (
+	Muharram      = 1
+	Safar         = 2
+	RabiAlAwwal   = 3
+	RabiAlThani   = 4
+	JumadaAlUla   = 5
+	JumadaAlThani = 6
+	Rajab         = 7
+	Shaban        = 8
+	Ramadan       = 9
+	Shawwal       = 10
+	DhuAlQadah    = 11
+	DhuAlHijjah   = 12
+)
+
+const islamicEpoch = 227015 // Muharram 1, 1 AH: July 16, 622 (Julian)
+
+// IslamicDate returns the Gregorian date of a day of the tabular Islamic
+// calendar: months alternate between 30 and 29 days, and 11 years in every
+// 30 add a day to Dhu al-Hijjah. Where the months begin with the sighting
+// of the crescent, holidays may fall a day or two from these dates.
+func IslamicDate(year, month, day int) time.Time {
+	return fromFixed(islamicEpoch - 1 + (year-1)*354 + floorDiv(3+11*year, 30) + 29*(month-1) + (6*month-1)/11 + day)
+}
+
+// IslamicDates returns the Gregorian dates of a day of the tabular Islamic
+// calendar that fall in the Gregorian year: the Islamic year being eleven
+// days shorter, there is usually one, sometimes two.
+func IslamicDates(year, month, day int) []time.Time {
+	// The Islamic year in which the Gregorian one begins.
+	first := floorDiv(30*(toFixed(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC))-islamicEpoch)+10646, 10631)
+
+	var dates []time.Time
+	for islamicYear := first; islamicYear <= first+2; islamicYear++ {
+		if date := IslamicDate(islamicYear, month, day); date.Year() == year {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// EidAlFitr returns the first days of Shawwal, ending Ramadan, in the
+// Gregorian year by the tabular calendar.
+func EidAlFitr(year int) []time.Time {
+	return IslamicDates(year, Shawwal, 1)
+}
+
+// floorDiv divides rounding towards negative infinity, as the calendar
+// formulas need for dates before their epochs.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,90 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEidAlFitr(t *testing.T) {
+	// Dates observed in Saudi Arabia; the tabular calendar may run a day or
+	// two apart from the sighting of the crescent.
+	observed := []string{
+		"1990-04-26", "1995-03-02", "2000-01-08", "2000-12-27", "2005-11-03",
+		"2010-09-10", "2015-07-17", "2018-06-15", "2019-06-04", "2020-05-24",
+		"2021-05-13", "2022-05-02", "2023-04-21", "2024-04-10", "2025-03-30",
+	}
+
+	for _, date := range observed {
+		expected, _ := time.Parse("2006-01-02", date)
+		found := false
+		for _, eid := range EidAlFitr(expected.Year()) {
+			if diff := eid.Sub(expected).Hours() / 24; diff >= -2 && diff <= 2 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Eid al-Fitr %s: got %v", date, EidAlFitr(expected.Year()))
+		}
+	}
+}
+
+func TestIslamicDates(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected []string
+	}{
+		{2000, []string{"2000-01-08", "2000-12-28"}},
+		{2001, []string{"2001-12-17"}},
+		{2024, []string{"2024-04-10"}},
+		{2025, []string{"2025-03-31"}},
+		{2026, []string{"2026-03-20"}},
+		{2033, []string{"2033-01-03", "2033-12-23"}},
+	}
+
+	for _, tt := range tests {
+		dates := EidAlFitr(tt.year)
+		if len(dates) != len(tt.expected) {
+			t.Errorf("EidAlFitr(%d): expected %v, got %v", tt.year, tt.expected, dates)
+			continue
+		}
+		for i, date := range dates {
+			if got := date.Format("2006-01-02"); got != tt.expected[i] {
+				t.Errorf("EidAlFitr(%d): expected %s, got %s", tt.year, tt.expected[i], got)
+			}
+		}
+	}
+}
+
+func TestIslamicYearLength(t *testing.T) {
+	// A tabular year has 354 days, or 355 in 11 years of every 30.
+	for cycle := 40; cycle < 60; cycle++ {
+		leapYears := 0
+		for year := cycle*30 + 1; year <= cycle*30+30; year++ {
+			length := toFixed(IslamicDate(year+1, Muharram, 1)) - toFixed(IslamicDate(year, Muharram, 1))
+			switch length {
+			case 354:
+			case 355:
+				leapYears++
+			default:
+				t.Errorf("Islamic year %d has %d days", year, length)
+			}
+		}
+		if leapYears != 11 {
+			t.Errorf("Islamic cycle from %d has %d leap years", cycle*30+1, leapYears)
+		}
+	}
+}
+
+func TestFloorDiv(t *testing.T) {
+	tests := []struct{ a, b, expected int }{
+		{7, 2, 3},
+		{-7, 2, -4},
+		{-6, 2, -3},
+		{0, 30, 0},
+	}
+	for _, tt := range tests {
+		if got := floorDiv(tt.a, tt.b); got != tt.expected {
+			t.Errorf("floorDiv(%d, %d) = %d; want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package holidays
+
+import "time"
+
+// RomanianHolidayProvider supplies the days off of Romania by the labour
+// code, with Easter and Pentecost kept by the Orthodox calendar.
+type RomanianHolidayProvider struct{}
+
+func (p *RomanianHolidayProvider) GetHolidays(year int) []Holiday {
+	easter := calculateOrthodoxEaster(year)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	holidays := []Holiday{
+		{ID: "new-years-day", Name: "Anul Nou", EnglishName: "New Year's Day", Date: date(1, 1)},
+		{ID: "day-after-new-years-day", Name: "A doua zi de Anul Nou", EnglishName: "Day after New Year's Day", Date: date(1, 2)},
+		{ID: "easter-sunday", Name: "Paștele", EnglishName: "Orthodox Easter Sunday", Date: easter},
+		{ID: "easter-monday", Name: "A doua zi de Paște", EnglishName: "Orthodox Easter Monday", Date: easter.AddDate(0, 0, 1)},
+		{ID: "labour-day", Name: "Ziua Muncii", EnglishName: "Labour Day", Date: date(5, 1)},
+		{ID: "whit-sunday", Name: "Rusaliile", EnglishName: "Orthodox Pentecost", Date: easter.AddDate(0, 0, 49)},
+		{ID: "whit-monday", Name: "A doua zi de Rusalii", EnglishName: "Orthodox Whit Monday", Date: easter.AddDate(0, 0, 50)},
+		{ID: "assumption-day", Name: "Adormirea Maicii Domnului", EnglishName: "Assumption Day", Date: date(8, 15)},
+		{ID: "st-andrews-day", Name: "Sfântul Andrei", EnglishName: "St. Andrew's Day", Date: date(11, 30)},
+		{ID: "national-day", Name: "Ziua Națională a României", EnglishName: "National Day", Date: date(12, 1)},
+		{ID: "christmas-day", Name: "Crăciunul", EnglishName: "Christmas Day", Date: date(12, 25)},
+		{ID: "second-day-of-christmas", Name: "A doua zi de Crăciun", EnglishName: "Second Day of Christmas", Date: date(12, 26)},
+	}
+	if year >= 2017 {
+		holidays = append(holidays,
+			Holiday{ID: "union-day", Name: "Ziua Unirii Principatelor Române", EnglishName: "Union Day", Date: date(1, 24)},
+			Holiday{ID: "childrens-day", Name: "Ziua Copilului", EnglishName: "Children's Day", Date: date(6, 1)})
+	}
+	if year >= 2018 {
+		holidays = append(holidays, Holiday{ID: "good-friday", Name: "Vinerea Mare", EnglishName: "Orthodox Good Friday", Date: easter.AddDate(0, 0, -2)})
+	}
+	if year >= 2024 {
+		holidays = append(holidays,
+			Holiday{ID: "epiphany", Name: "Boboteaza", EnglishName: "Epiphany", Date: date(1, 6)},
+			Holiday{ID: "st-john-the-baptist", Name: "Soborul Sfântului Ioan Botezătorul", EnglishName: "St. John the Baptist", Date: date(1, 7)})
+	}
+
+	for i := range holidays {
+		holidays[i].Language = "ro"
+		holidays[i].Source = "Legea nr. 53/2003 (Codul muncii), art. 139"
+	}
+	return holidays
+}
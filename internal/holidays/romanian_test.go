@@ -0,0 +1,42 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRomanianHolidayProvider(t *testing.T) {
+	provider := &RomanianHolidayProvider{}
+
+	tests := []struct {
+		year     int
+		expected int
+	}{
+		{2016, 12},
+		{2017, 14}, // Union Day and Children's Day
+		{2018, 15}, // Good Friday
+		{2024, 17}, // Epiphany and St. John the Baptist
+	}
+	for _, tt := range tests {
+		if got := len(provider.GetHolidays(tt.year)); got != tt.expected {
+			t.Errorf("GetHolidays(%d) returned %d holidays; want %d", tt.year, got, tt.expected)
+		}
+	}
+
+	expected := map[string]string{
+		"good-friday":   "2024-05-03",
+		"easter-sunday": "2024-05-05",
+		"easter-monday": "2024-05-06",
+		"whit-sunday":   "2024-06-23",
+		"whit-monday":   "2024-06-24",
+		"national-day":  "2024-12-01",
+	}
+	for _, holiday := range provider.GetHolidays(2024) {
+		if holiday.Language != "ro" || holiday.EnglishName == "" || holiday.Source == "" {
+			t.Errorf("%s has incomplete metadata: %+v", holiday.Name, holiday)
+		}
+		if date, ok := expected[holiday.ID]; ok && holiday.Date.Format(time.DateOnly) != date {
+			t.Errorf("%s on %s; want %s", holiday.ID, holiday.Date.Format(time.DateOnly), date)
+		}
+	}
+}
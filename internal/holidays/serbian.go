@@ -0,0 +1,41 @@
+package holidays
+
+import "time"
+
+// SerbianHolidayProvider supplies the state holidays of Serbia, with
+// Christmas and Easter kept by the Orthodox calendar. A day of a state
+// holiday that falls on a Sunday gives the first working day after it off.
+type SerbianHolidayProvider struct{}
+
+func (p *SerbianHolidayProvider) GetHolidays(year int) []Holiday {
+	easter := calculateOrthodoxEaster(year)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	holidays := []Holiday{
+		{ID: "new-years-day", Name: "Нова година", EnglishName: "New Year's Day", Date: date(1, 1)},
+		{ID: "day-after-new-years-day", Name: "Нова година, други дан", EnglishName: "Day after New Year's Day", Date: date(1, 2)},
+		{ID: "statehood-day", Name: "Сретење – Дан државности Србије", EnglishName: "Statehood Day", Date: date(2, 15)},
+		{ID: "day-after-statehood-day", Name: "Сретење – Дан државности Србије, други дан", EnglishName: "Day after Statehood Day", Date: date(2, 16)},
+		{ID: "labour-day", Name: "Празник рада", EnglishName: "Labour Day", Date: date(5, 1)},
+		{ID: "day-after-labour-day", Name: "Празник рада, други дан", EnglishName: "Day after Labour Day", Date: date(5, 2)},
+		{ID: "armistice-day", Name: "Дан примирја у Првом светском рату", EnglishName: "Armistice Day", Date: date(11, 11)},
+		{ID: "christmas-day", Name: "Божић", EnglishName: "Orthodox Christmas Day", Date: date(1, 7)},
+		{ID: "good-friday", Name: "Велики петак", EnglishName: "Orthodox Good Friday", Date: easter.AddDate(0, 0, -2)},
+		{ID: "easter-sunday", Name: "Васкрс", EnglishName: "Orthodox Easter Sunday", Date: easter},
+		{ID: "easter-monday", Name: "Васкрсни понедељак", EnglishName: "Orthodox Easter Monday", Date: easter.AddDate(0, 0, 1)},
+		{ID: "vidovdan", Name: "Видовдан", EnglishName: "Vidovdan", Date: date(6, 28), Type: Observance},
+	}
+
+	religious := map[string]bool{"christmas-day": true, "good-friday": true, "easter-sunday": true, "easter-monday": true, "vidovdan": true}
+	sunday := func(day time.Weekday) bool { return day == time.Sunday }
+	isState := func(holiday Holiday) bool { return !religious[holiday.ID] }
+	holidays = append(holidays, substituteDays(holidays, sunday, isState, "нерадни дан")...)
+
+	for i := range holidays {
+		holidays[i].Language = "sr"
+		holidays[i].Source = "Закон о државним и другим празницима у Републици Србији"
+	}
+	return holidays
+}
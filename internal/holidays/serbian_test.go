@@ -0,0 +1,38 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSerbianHolidayProvider(t *testing.T) {
+	tests := []struct {
+		year     int
+		id       string
+		expected string // empty when there is none
+	}{
+		{2024, "christmas-day", "2024-01-07"},
+		{2024, "good-friday", "2024-05-03"},
+		{2024, "easter-monday", "2024-05-06"},
+		{2023, "new-years-day-substitute", "2023-01-03"},        // January 1 on Sunday, 2 is a holiday
+		{2021, "day-after-labour-day-substitute", "2021-05-04"}, // May 2 on Sunday, 3 is Easter Monday
+		{2021, "day-after-new-years-day-substitute", ""},        // only Sundays are moved
+		{2020, "armistice-day-substitute", ""},                  // Wednesday
+		{2024, "christmas-day-substitute", ""},                  // a Sunday, but religious
+		{2022, "day-after-statehood-day-substitute", ""},        // Wednesday
+		{2026, "statehood-day-substitute", "2026-02-17"},        // February 15 on Sunday
+		{2021, "vidovdan", "2021-06-28"},
+	}
+
+	for _, tt := range tests {
+		found := ""
+		for _, holiday := range (&SerbianHolidayProvider{}).GetHolidays(tt.year) {
+			if holiday.ID == tt.id {
+				found = holiday.Date.Format(time.DateOnly)
+			}
+		}
+		if found != tt.expected {
+			t.Errorf("%s in %d on %q; want %q", tt.id, tt.year, found, tt.expected)
+		}
+	}
+}
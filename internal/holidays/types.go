@@ -0,0 +1,94 @@
+package holidays
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HolidayType is the legal category of a holiday. The zero value is
+// Public, so providers that do not distinguish categories need not set it.
+type HolidayType int
+
+const (
+	Public     HolidayType = iota // a day off for everyone by law
+	Bank                          // banks and public offices close, others work
+	Observance                    // commemorated, but a working day
+	Optional                      // a day off only for some workers or in some municipalities
+	Regional                      // a day off for everyone in some regions of the country
+)
+
+var holidayTypeNames = [...]string{"public", "bank", "observance", "optional", "regional"}
+
+func (t HolidayType) String() string {
+	return holidayTypeNames[t]
+}
+
+// HolidayTypes is a set of holiday types, one bit per HolidayType.
+type HolidayTypes uint8
+
+// DefaultHolidayTypes are the holidays that are a day off for everyone
+// where they apply: public and regional ones.
+const DefaultHolidayTypes = HolidayTypes(1<<Public | 1<<Regional)
+
+// AllHolidayTypes holds every holiday type.
+const AllHolidayTypes = HolidayTypes(1<<len(holidayTypeNames) - 1)
+
+// TypesOf returns the set of the given types.
+func TypesOf(types ...HolidayType) HolidayTypes {
+	var set HolidayTypes
+	for _, t := range types {
+		set |= 1 << t
+	}
+	return set
+}
+
+// Has reports whether t is in the set.
+func (s HolidayTypes) Has(t HolidayType) bool {
+	return s&(1<<t) != 0
+}
+
+func (s HolidayTypes) String() string {
+	var names []string
+	for t, name := range holidayTypeNames {
+		if s.Has(HolidayType(t)) {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// HolidayTypeError reports an unknown holiday type. Types lists the known
+// ones.
+type HolidayTypeError struct {
+	Type  string
+	Types []string
+}
+
+func (e *HolidayTypeError) Error() string {
+	return fmt.Sprintf("invalid holiday type: %s (use %s or all)", e.Type, strings.Join(e.Types, ", "))
+}
+
+// ParseHolidayTypes parses a comma-separated list of holiday types such as
+// "public,bank", or "all". An unknown type is reported with a
+// *HolidayTypeError.
+func ParseHolidayTypes(value string) (HolidayTypes, error) {
+	if strings.EqualFold(strings.TrimSpace(value), "all") {
+		return AllHolidayTypes, nil
+	}
+
+	var set HolidayTypes
+	for _, part := range strings.Split(value, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		found := false
+		for t, typeName := range holidayTypeNames {
+			if name == typeName {
+				set |= 1 << t
+				found = true
+			}
+		}
+		if !found {
+			return 0, &HolidayTypeError{Type: part, Types: holidayTypeNames[:]}
+		}
+	}
+	return set, nil
+}
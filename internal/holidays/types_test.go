@@ -0,0 +1,59 @@
+package holidays
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHolidayTypeString(t *testing.T) {
+	tests := map[HolidayType]string{Public: "public", Bank: "bank", Observance: "observance", Optional: "optional", Regional: "regional"}
+	for holidayType, expected := range tests {
+		if holidayType.String() != expected {
+			t.Errorf("HolidayType(%d).String() = %q, want %q", holidayType, holidayType.String(), expected)
+		}
+	}
+}
+
+func TestParseHolidayTypes(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected HolidayTypes
+		valid    bool
+	}{
+		{"public", TypesOf(Public), true},
+		{"public,bank", TypesOf(Public, Bank), true},
+		{" Regional , optional ", TypesOf(Regional, Optional), true},
+		{"all", AllHolidayTypes, true},
+		{"public,weekend", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := ParseHolidayTypes(tt.value)
+			if (err == nil) != tt.valid || result != tt.expected {
+				t.Errorf("ParseHolidayTypes(%q) = %v, %v; want %v", tt.value, result, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseHolidayTypesError(t *testing.T) {
+	_, err := ParseHolidayTypes("public,weekend")
+	var typeErr *HolidayTypeError
+	if !errors.As(err, &typeErr) || typeErr.Type != "weekend" {
+		t.Fatalf("ParseHolidayTypes error = %v, want a *HolidayTypeError for weekend", err)
+	}
+	if expected := "invalid holiday type: weekend (use public, bank, observance, optional, regional or all)"; err.Error() != expected {
+		t.Errorf("Error() = %q, want %q", err.Error(), expected)
+	}
+}
+
+func TestHolidayTypes(t *testing.T) {
+	if !DefaultHolidayTypes.Has(Public) || !DefaultHolidayTypes.Has(Regional) || DefaultHolidayTypes.Has(Optional) {
+		t.Errorf("DefaultHolidayTypes = %v; want public,regional", DefaultHolidayTypes)
+	}
+	if got := AllHolidayTypes.String(); got != "public,bank,observance,optional,regional" {
+		t.Errorf("AllHolidayTypes.String() = %q", got)
+	}
+}
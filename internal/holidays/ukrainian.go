@@ -0,0 +1,69 @@
+package holidays
+
+import "time"
+
+// martialLaw is the day martial law was declared in Ukraine. It suspends
+// the days off of article 73 of the labour code, so the holidays since then
+// are only observances, and no days off are moved from a weekend.
+var martialLaw = time.Date(2022, time.February, 24, 0, 0, 0, 0, time.UTC)
+
+// UkrainianHolidayProvider supplies the holidays of Ukraine, with Easter
+// and Trinity kept by the Orthodox calendar. Before martial law, a holiday
+// that fell on a weekend gave the first working day after it off.
+type UkrainianHolidayProvider struct{}
+
+func (p *UkrainianHolidayProvider) GetHolidays(year int) []Holiday {
+	easter := calculateOrthodoxEaster(year)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	holidays := []Holiday{
+		{ID: "new-years-day", Name: "Новий рік", EnglishName: "New Year's Day", Date: date(1, 1)},
+		{ID: "womens-day", Name: "Міжнародний жіночий день", EnglishName: "International Women's Day", Date: date(3, 8)},
+		{ID: "easter-sunday", Name: "Великдень", EnglishName: "Orthodox Easter Sunday", Date: easter},
+		{ID: "labour-day", Name: "День праці", EnglishName: "Labour Day", Date: date(5, 1)},
+		{ID: "whit-sunday", Name: "Трійця", EnglishName: "Orthodox Pentecost", Date: easter.AddDate(0, 0, 49)},
+		{ID: "constitution-day", Name: "День Конституції України", EnglishName: "Constitution Day", Date: date(6, 28)},
+		{ID: "independence-day", Name: "День Незалежності України", EnglishName: "Independence Day", Date: date(8, 24)},
+	}
+	if year <= 2023 {
+		holidays = append(holidays,
+			Holiday{ID: "orthodox-christmas-day", Name: "Різдво Христове", EnglishName: "Orthodox Christmas Day", Date: date(1, 7)},
+			Holiday{ID: "victory-day", Name: "День перемоги над нацизмом у Другій світовій війні", EnglishName: "Victory Day", Date: date(5, 9)})
+	} else {
+		holidays = append(holidays, Holiday{ID: "remembrance-day", Name: "День пам'яті та перемоги над нацизмом у Другій світовій війні", EnglishName: "Day of Remembrance and Victory", Date: date(5, 8)})
+	}
+	if year <= 2017 {
+		holidays = append(holidays, Holiday{ID: "day-after-labour-day", Name: "День праці, другий день", EnglishName: "Day after Labour Day", Date: date(5, 2)})
+	}
+	if year >= 2017 {
+		holidays = append(holidays, Holiday{ID: "christmas-day", Name: "Різдво Христове", EnglishName: "Christmas Day", Date: date(12, 25)})
+	}
+	switch {
+	case year >= 2015 && year <= 2022:
+		holidays = append(holidays, Holiday{ID: "defenders-day", Name: "День захисника України", EnglishName: "Defenders Day", Date: date(10, 14)})
+	case year >= 2023:
+		holidays = append(holidays, Holiday{ID: "defenders-day", Name: "День захисників і захисниць України", EnglishName: "Defenders Day", Date: date(10, 1)})
+	}
+	switch {
+	case year == 2022:
+		holidays = append(holidays, Holiday{ID: "statehood-day", Name: "День Української Державності", EnglishName: "Statehood Day", Date: date(7, 28)})
+	case year >= 2023:
+		holidays = append(holidays, Holiday{ID: "statehood-day", Name: "День Української Державності", EnglishName: "Statehood Day", Date: date(7, 15)})
+	}
+
+	weekend := func(day time.Weekday) bool { return day == time.Saturday || day == time.Sunday }
+	beforeMartialLaw := func(holiday Holiday) bool { return holiday.Date.Before(martialLaw) }
+	holidays = append(holidays, substituteDays(holidays, weekend, beforeMartialLaw, "вихідний")...)
+
+	for i := range holidays {
+		holidays[i].Language = "uk"
+		holidays[i].Source = "Кодекс законів про працю України, ст. 73"
+		if !holidays[i].Date.Before(martialLaw) {
+			holidays[i].Type = Observance
+			holidays[i].Source = "Закон України № 2136-IX"
+		}
+	}
+	return holidays
+}
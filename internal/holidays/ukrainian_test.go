@@ -0,0 +1,45 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUkrainianHolidayProvider(t *testing.T) {
+	tests := []struct {
+		year     int
+		id       string
+		date     string
+		expected HolidayType
+	}{
+		{2021, "whit-sunday", "2021-06-20", Public},
+		{2021, "whit-sunday-substitute", "2021-06-21", Public},
+		{2021, "labour-day-substitute", "2021-05-03", Public},
+		{2021, "easter-sunday-substitute", "2021-05-04", Public},
+		{2021, "defenders-day", "2021-10-14", Public},
+		{2022, "new-years-day-substitute", "2022-01-03", Public}, // before martial law
+		{2022, "easter-sunday", "2022-04-24", Observance},
+		{2024, "christmas-day", "2024-12-25", Observance},
+		{2024, "defenders-day", "2024-10-01", Observance},
+		{2024, "statehood-day", "2024-07-15", Observance},
+	}
+
+	for _, tt := range tests {
+		var found *Holiday
+		holidays := (&UkrainianHolidayProvider{}).GetHolidays(tt.year)
+		for i := range holidays {
+			if holidays[i].ID == tt.id {
+				found = &holidays[i]
+			}
+		}
+		if found == nil || found.Date.Format(time.DateOnly) != tt.date || found.Type != tt.expected {
+			t.Errorf("%s in %d = %+v; want %s, %s", tt.id, tt.year, found, tt.date, tt.expected)
+		}
+	}
+
+	for _, holiday := range (&UkrainianHolidayProvider{}).GetHolidays(2024) {
+		if holiday.ID == "orthodox-christmas-day" || holiday.ID == "easter-sunday-substitute" {
+			t.Errorf("%s should not be in 2024", holiday.ID)
+		}
+	}
+}
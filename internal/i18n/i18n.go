@@ -0,0 +1,189 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lang is a supported output language.
+type Lang string
+
+const (
+	English Lang = "en"
+	Czech   Lang = "cs"
+)
+
+// Parse recognises a language code or a POSIX locale such as "cs_CZ.UTF-8".
+func Parse(value string) (Lang, bool) {
+	code := strings.ToLower(value)
+	if i := strings.IndexAny(code, "_-.@"); i >= 0 {
+		code = code[:i]
+	}
+	switch code {
+	case "en":
+		return English, true
+	case "cs", "cz":
+		return Czech, true
+	}
+	return "", false
+}
+
+// Detect returns the language given by --lang, or else by the locale
+// environment (LC_ALL, LC_MESSAGES, then LANG). An unsupported locale falls
+// back to English; an unsupported --lang is an error.
+func Detect(value string) (Lang, error) {
+	if value != "" {
+		lang, ok := Parse(value)
+		if !ok {
+			return "", fmt.Errorf("unsupported language: %s (use en or cs)", value)
+		}
+		return lang, nil
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			if lang, ok := Parse(locale); ok {
+				return lang, nil
+			}
+			return English, nil
+		}
+	}
+	return English, nil
+}
+
+// Case is a grammatical case. Czech month names change with it: "červenec
+// 2024", "od července", "v červenci". English ignores it.
+type Case int
+
+const (
+	Nominative Case = iota
+	Genitive
+	Locative
+)
+
+var czechMonths = [12][3]string{
+	{"leden", "ledna", "lednu"},
+	{"únor", "února", "únoru"},
+	{"březen", "března", "březnu"},
+	{"duben", "dubna", "dubnu"},
+	{"květen", "května", "květnu"},
+	{"červen", "června", "červnu"},
+	{"červenec", "července", "červenci"},
+	{"srpen", "srpna", "srpnu"},
+	{"září", "září", "září"},
+	{"říjen", "října", "říjnu"},
+	{"listopad", "listopadu", "listopadu"},
+	{"prosinec", "prosince", "prosinci"},
+}
+
+var czechWeekdays = [7]string{"neděle", "pondělí", "úterý", "středa", "čtvrtek", "pátek", "sobota"}
+
+// PluralIndex returns which plural form to use for n: English has one and
+// other; Czech has one (1 den), few (2–4 dny) and other (0, 5+ dní).
+func PluralIndex(lang Lang, n int) int {
+	if n < 0 {
+		n = -n
+	}
+	switch lang {
+	case Czech:
+		switch {
+		case n == 1:
+			return 0
+		case n >= 2 && n <= 4:
+			return 1
+		default:
+			return 2
+		}
+	default:
+		if n == 1 {
+			return 0
+		}
+		return 1
+	}
+}
+
+// Capitalize upper-cases the first letter of s, e.g. for a Czech month name
+// at the start of a sentence.
+func Capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// Messages are the translations of one language. Text maps English source
+// text, usually a format string, to its translation; Plural maps a message
+// ID to its forms in PluralIndex order.
+type Messages struct {
+	Text   map[string]string
+	Plural map[string][]string
+}
+
+// Catalog holds the messages of every language. English text needs no
+// entry, but English plural forms do.
+type Catalog map[Lang]Messages
+
+// Printer renders messages in one language.
+type Printer struct {
+	Lang    Lang
+	catalog Catalog
+}
+
+// NewPrinter returns a printer for lang using the messages of catalog.
+func NewPrinter(lang Lang, catalog Catalog) *Printer {
+	return &Printer{Lang: lang, catalog: catalog}
+}
+
+// Text returns the translation of s, or s itself when there is none.
+func (p *Printer) Text(s string) string {
+	if translated, ok := p.catalog[p.Lang].Text[s]; ok {
+		return translated
+	}
+	return s
+}
+
+// Sprintf formats the translation of format.
+func (p *Printer) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(p.Text(format), args...)
+}
+
+// Errorf returns an error with the translation of format.
+func (p *Printer) Errorf(format string, args ...any) error {
+	return fmt.Errorf(p.Text(format), args...)
+}
+
+// Plural formats the form of message id that agrees with n, falling back
+// to English when the language has no translation.
+func (p *Printer) Plural(id string, n int, args ...any) string {
+	lang := p.Lang
+	forms, ok := p.catalog[lang].Plural[id]
+	if !ok {
+		lang = English
+		forms = p.catalog[English].Plural[id]
+	}
+	if len(forms) == 0 {
+		return id
+	}
+	return fmt.Sprintf(forms[min(PluralIndex(lang, n), len(forms)-1)], args...)
+}
+
+// Month returns the name of month in the given case, lower-case in Czech.
+func (p *Printer) Month(month time.Month, c Case) string {
+	if p.Lang == Czech {
+		return czechMonths[month-1][c]
+	}
+	return month.String()
+}
+
+// Weekday returns the name of day.
+func (p *Printer) Weekday(day time.Weekday) string {
+	if p.Lang == Czech {
+		return czechWeekdays[day]
+	}
+	return day.String()
+}
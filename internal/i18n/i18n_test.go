@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Lang
+		ok       bool
+	}{
+		{"en", English, true},
+		{"en_US.UTF-8", English, true},
+		{"cs", Czech, true},
+		{"cs_CZ.UTF-8", Czech, true},
+		{"CZ", Czech, true},
+		{"de_DE", "", false},
+		{"C", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			lang, ok := Parse(tt.value)
+			if lang != tt.expected || ok != tt.ok {
+				t.Errorf("Parse(%q) = %q, %v; want %q, %v", tt.value, lang, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     string
+		lcAll    string
+		lang     string
+		expected Lang
+		wantErr  bool
+	}{
+		{name: "Default", expected: English},
+		{name: "LANG", lang: "cs_CZ.UTF-8", expected: Czech},
+		{name: "LC_ALL wins over LANG", lcAll: "en_GB.UTF-8", lang: "cs_CZ.UTF-8", expected: English},
+		{name: "Unsupported locale", lang: "de_DE.UTF-8", expected: English},
+		{name: "Flag wins", flag: "cs", lcAll: "en_US.UTF-8", expected: Czech},
+		{name: "Unsupported flag", flag: "de", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", "")
+			t.Setenv("LANG", tt.lang)
+
+			lang, err := Detect(tt.flag)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Detect() should return an error")
+				}
+				return
+			}
+			if err != nil || lang != tt.expected {
+				t.Errorf("Detect(%q) = %q, %v; want %q", tt.flag, lang, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPluralIndex(t *testing.T) {
+	tests := []struct {
+		lang     Lang
+		n        int
+		expected int
+	}{
+		{English, 0, 1},
+		{English, 1, 0},
+		{English, 2, 1},
+		{Czech, 0, 2},
+		{Czech, 1, 0},
+		{Czech, 2, 1},
+		{Czech, 4, 1},
+		{Czech, 5, 2},
+		{Czech, 22, 2},
+		{Czech, -3, 1},
+	}
+
+	for _, tt := range tests {
+		if got := PluralIndex(tt.lang, tt.n); got != tt.expected {
+			t.Errorf("PluralIndex(%s, %d) = %d; want %d", tt.lang, tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	catalog := Catalog{
+		English: {Plural: map[string][]string{"days": {"%d day", "%d days"}}},
+		Czech: {
+			Text:   map[string]string{"invalid month: %s": "neplatný měsíc: %s"},
+			Plural: map[string][]string{"days": {"%d den", "%d dny", "%d dní"}},
+		},
+	}
+
+	en := NewPrinter(English, catalog)
+	cs := NewPrinter(Czech, catalog)
+
+	if got := en.Sprintf("invalid month: %s", "x"); got != "invalid month: x" {
+		t.Errorf("English Sprintf() = %q", got)
+	}
+	if got := cs.Errorf("invalid month: %s", "x").Error(); got != "neplatný měsíc: x" {
+		t.Errorf("Czech Errorf() = %q", got)
+	}
+	if got := cs.Text("untranslated"); got != "untranslated" {
+		t.Errorf("Text() fallback = %q", got)
+	}
+
+	for n, expected := range map[int]string{1: "1 den", 2: "2 dny", 5: "5 dní", 0: "0 dní"} {
+		if got := cs.Plural("days", n, n); got != expected {
+			t.Errorf("Czech Plural(%d) = %q; want %q", n, got, expected)
+		}
+	}
+	for n, expected := range map[int]string{1: "1 day", 2: "2 days"} {
+		if got := en.Plural("days", n, n); got != expected {
+			t.Errorf("English Plural(%d) = %q; want %q", n, got, expected)
+		}
+	}
+}
+
+func TestMonth(t *testing.T) {
+	cs := NewPrinter(Czech, nil)
+	en := NewPrinter(English, nil)
+
+	tests := []struct {
+		printer  *Printer
+		c        Case
+		expected string
+	}{
+		{cs, Nominative, "červenec"},
+		{cs, Genitive, "července"},
+		{cs, Locative, "červenci"},
+		{en, Locative, "July"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.printer.Month(time.July, tt.c); got != tt.expected {
+			t.Errorf("Month(July, %d) = %q; want %q", tt.c, got, tt.expected)
+		}
+	}
+
+	if got := cs.Weekday(time.Wednesday); got != "středa" {
+		t.Errorf("Weekday() = %q", got)
+	}
+	if got := Capitalize("červenec 2024"); got != "Červenec 2024" {
+		t.Errorf("Capitalize() = %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+// Package ical writes all-day events as an iCalendar file (RFC 5545) that
+// calendar applications can import or subscribe to.
+package ical
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/holidays"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event is an all-day event spanning Start through End inclusive.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time // zero for a single day
+	Summary     string
+	Description string
+	Categories  []string
+	Busy        bool // shown as busy time; holidays are free, vacation is busy
+}
+
+// Calendar is a named list of events.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// HolidayEvents returns an event for each holiday of a country, and of its
+// region when one is given, named in the language lang with the other name
+// as the description. The UIDs depend only on the date, the country and the
+// holiday's ID, e.g. 20201001-cn-national-day@billme, so importing an
+// updated calendar again replaces its events instead of duplicating them,
+// and two holidays on the same date keep their own events. Holidays of a
+// combined calendar carry their own country, which replaces the given one
+// and is added to the summary.
+func HolidayEvents(list []holidays.Holiday, country, region, lang string) []Event {
+	scope := strings.ToLower(country)
+	if region != "" {
+		scope += "-" + strings.ToLower(region)
+	}
+
+	events := make([]Event, 0, len(list))
+	for _, holiday := range list {
+		summary, description := holiday.NameIn(lang), holiday.EnglishName
+		if summary != holiday.Name {
+			description = holiday.Name
+		}
+		event := Event{
+			UID:         holidayUID(holiday, scope),
+			Start:       holiday.Date,
+			Summary:     summary,
+			Description: description,
+			Categories:  []string{"Holiday", strings.ToUpper(country)},
+		}
+		if holiday.Country != "" {
+			event.UID = holidayUID(holiday, strings.ToLower(holiday.Country))
+			event.Summary += " (" + holiday.Country + ")"
+			event.Categories[1] = holiday.Country
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func holidayUID(holiday holidays.Holiday, scope string) string {
+	uid := holiday.Date.Format("20060102") + "-" + scope
+	if holiday.ID != "" {
+		uid += "-" + holiday.ID
+	}
+	return uid + "@billme"
+}
+
+// AbsenceEvents returns a busy event for each date of absence, with UIDs
+// as stable as those of HolidayEvents.
+func AbsenceEvents(dates []time.Time, summary string) []Event {
+	events := make([]Event, 0, len(dates))
+	for _, date := range dates {
+		events = append(events, Event{
+			UID:        date.Format("20060102") + "-vacation@billme",
+			Start:      date,
+			Summary:    summary,
+			Categories: []string{"Vacation"},
+			Busy:       true,
+		})
+	}
+	return events
+}
+
+// Write renders the calendar with CRLF line endings, folding lines longer
+// than 75 octets. stamp is written as DTSTAMP, the time the file was made.
+func Write(w io.Writer, calendar Calendar, stamp time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//billme//billme//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	if calendar.Name != "" {
+		lines = append(lines, "X-WR-CALNAME:"+escape(calendar.Name))
+	}
+
+	for _, event := range calendar.Events {
+		end := event.End
+		if end.IsZero() {
+			end = event.Start
+		}
+		transparency := "TRANSPARENT"
+		if event.Busy {
+			transparency = "OPAQUE"
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+event.UID,
+			"DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"),
+			"DTSTART;VALUE=DATE:"+event.Start.Format("20060102"),
+			// DTEND of an all-day event is exclusive.
+			"DTEND;VALUE=DATE:"+end.AddDate(0, 0, 1).Format("20060102"),
+			"SUMMARY:"+escape(event.Summary),
+		)
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+escape(event.Description))
+		}
+		if len(event.Categories) > 0 {
+			categories := make([]string, len(event.Categories))
+			for i, category := range event.Categories {
+				categories[i] = escape(category)
+			}
+			lines = append(lines, "CATEGORIES:"+strings.Join(categories, ","))
+		}
+		lines = append(lines, "TRANSP:"+transparency, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, fold(line)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escape escapes a TEXT value: backslashes, semicolons, commas and
+// newlines.
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// fold splits a content line into lines of at most 75 octets, continuing
+// each with a space, without breaking a UTF-8 sequence, and ends it with
+// CRLF.
+func fold(line string) string {
+	const limit = 75
+
+	var b strings.Builder
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		fmt.Fprintf(&b, "%s\r\n ", line[:cut])
+		line = line[cut:]
+		width = limit - 1 // the leading space counts
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,173 @@
+package ical
+
+import (
+	"github.com/honzahovorka/billme/internal/holidays"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(year, month, day int) time.Time {
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestWrite(t *testing.T) {
+	calendar := Calendar{
+		Name: "Holidays, vacation",
+		Events: []Event{
+			{UID: "a@billme", Start: date(2024, 7, 6), Summary: "Den upálení mistra Jana Husa", Categories: []string{"Holiday", "CZ"}},
+			{UID: "b@billme", Start: date(2024, 7, 8), End: date(2024, 7, 12), Summary: "Vacation; beach", Busy: true},
+		},
+	}
+
+	var b strings.Builder
+	if err := Write(&b, calendar, time.Date(2024, 7, 1, 8, 30, 0, 0, time.FixedZone("CEST", 2*3600))); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	output := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Holidays\\, vacation\r\n",
+		"UID:a@billme\r\nDTSTAMP:20240701T063000Z\r\nDTSTART;VALUE=DATE:20240706\r\nDTEND;VALUE=DATE:20240707\r\n",
+		"CATEGORIES:Holiday,CZ\r\nTRANSP:TRANSPARENT\r\n",
+		"DTSTART;VALUE=DATE:20240708\r\nDTEND;VALUE=DATE:20240713\r\nSUMMARY:Vacation\\; beach\r\n",
+		"TRANSP:OPAQUE\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Write() is missing %q in:\n%s", want, output)
+		}
+	}
+	if strings.Contains(strings.ReplaceAll(output, "\r\n", ""), "\n") {
+		t.Error("Write() output has bare LF line endings")
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"plain", "plain"},
+		{`a\b`, `a\\b`},
+		{"a;b,c", `a\;b\,c`},
+		{"line\nbreak", `line\nbreak`},
+		{"crlf\r\nbreak", `crlf\nbreak`},
+	}
+
+	for _, tt := range tests {
+		if result := escape(tt.text); result != tt.expected {
+			t.Errorf("escape(%q) = %q, want %q", tt.text, result, tt.expected)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"Short", "SUMMARY:Nový rok"},
+		{"Exactly 75 octets", "SUMMARY:" + strings.Repeat("x", 67)},
+		{"Long ASCII", "DESCRIPTION:" + strings.Repeat("abcdefghij", 20)},
+		{"Long UTF-8", "SUMMARY:" + strings.Repeat("Den upálení mistra Jana Husa ", 8)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := fold(tt.line)
+			if !strings.HasSuffix(folded, "\r\n") {
+				t.Fatalf("fold() = %q does not end with CRLF", folded)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+			unfolded := lines[0]
+			for i, line := range lines {
+				if len(line) > 75 {
+					t.Errorf("line %d has %d octets", i, len(line))
+				}
+				if i > 0 {
+					if !strings.HasPrefix(line, " ") {
+						t.Errorf("continuation line %d does not start with a space", i)
+					}
+					unfolded += line[1:]
+				}
+			}
+			if unfolded != tt.line {
+				t.Errorf("unfolded line = %q, want %q", unfolded, tt.line)
+			}
+			if len(tt.line) <= 75 && len(lines) != 1 {
+				t.Errorf("fold() split a short line: %q", folded)
+			}
+		})
+	}
+}
+
+func TestHolidayEvents(t *testing.T) {
+	list := []holidays.Holiday{{ID: "jan-hus-day", Name: "Den upálení mistra Jana Husa", EnglishName: "Jan Hus Day", Language: "cs", Date: date(2024, 7, 6)}}
+
+	tests := []struct {
+		region      string
+		lang        string
+		uid         string
+		summary     string
+		description string
+	}{
+		{"", "cs", "20240706-cz-jan-hus-day@billme", "Den upálení mistra Jana Husa", "Jan Hus Day"},
+		{"PHA", "en", "20240706-cz-pha-jan-hus-day@billme", "Jan Hus Day", "Den upálení mistra Jana Husa"},
+	}
+
+	for _, tt := range tests {
+		events := HolidayEvents(list, "cz", tt.region, tt.lang)
+		if len(events) != 1 || events[0].UID != tt.uid || events[0].Busy {
+			t.Fatalf("HolidayEvents(%q) = %+v; want one free event with UID %s", tt.region, events, tt.uid)
+		}
+		if events[0].Summary != tt.summary || events[0].Description != tt.description {
+			t.Errorf("HolidayEvents(%q) named %q, %q; want %q, %q", tt.lang, events[0].Summary, events[0].Description, tt.summary, tt.description)
+		}
+	}
+}
+
+func TestHolidayEventsCombined(t *testing.T) {
+	list := []holidays.Holiday{
+		{ID: "labour-day", Name: "Svátek práce", EnglishName: "Labour Day", Language: "cs", Date: date(2024, 5, 1), Country: "CZ"},
+		{ID: "labour-day", Name: "Tag der Arbeit", EnglishName: "Labour Day", Language: "de", Date: date(2024, 5, 1), Country: "DE-BY"},
+	}
+
+	events := HolidayEvents(list, "CZ+DE-BY", "", "en")
+	if len(events) != 2 || events[0].UID != "20240501-cz-labour-day@billme" || events[1].UID != "20240501-de-by-labour-day@billme" {
+		t.Fatalf("HolidayEvents() = %+v; want a UID for each country", events)
+	}
+	if events[1].Summary != "Labour Day (DE-BY)" || events[1].Categories[1] != "DE-BY" {
+		t.Errorf("HolidayEvents() = %+v; want the event attributed to DE-BY", events[1])
+	}
+}
+
+func TestHolidayEventsSameDate(t *testing.T) {
+	// In 2020 the Mid-Autumn Festival fell on National Day.
+	list := (&holidays.ChineseHolidayProvider{}).GetHolidays(2020)
+	uids := map[string]bool{}
+	for _, event := range HolidayEvents(list, "CN", "", "en") {
+		if uids[event.UID] {
+			t.Errorf("HolidayEvents() repeats UID %s", event.UID)
+		}
+		uids[event.UID] = true
+	}
+	for _, uid := range []string{"20201001-cn-national-day@billme", "20201001-cn-mid-autumn-festival@billme"} {
+		if !uids[uid] {
+			t.Errorf("HolidayEvents() has no event %s", uid)
+		}
+	}
+
+	events := HolidayEvents([]holidays.Holiday{{Name: "Company Day", Date: date(2024, 6, 14)}}, "CZ", "", "en")
+	if events[0].UID != "20240614-cz@billme" {
+		t.Errorf("HolidayEvents() UID %s; want 20240614-cz@billme for a holiday without ID", events[0].UID)
+	}
+}
+
+func TestAbsenceEvents(t *testing.T) {
+	events := AbsenceEvents([]time.Time{date(2024, 7, 8), date(2024, 7, 9)}, "Vacation")
+	if len(events) != 2 || events[1].UID != "20240709-vacation@billme" || !events[1].Busy {
+		t.Errorf("AbsenceEvents() = %+v; want two busy events", events)
+	}
+}
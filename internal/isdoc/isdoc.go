@@ -0,0 +1,534 @@
+package isdoc
+
+import (
+	"crypto/rand"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Namespace = "http://isdoc.cz/namespace/2013"
+	Version   = "6.0.2"
+
+	localCurrency       = "CZK"
+	documentTypeInvoice = 1
+	paymentBankTransfer = 42
+)
+
+// Party is a supplier or customer of the invoice.
+type Party struct {
+	ID             string // IČO
+	VATID          string // DIČ
+	Name           string
+	Street         string
+	BuildingNumber string
+	City           string
+	PostalCode     string
+	Country        string // ISO 3166-1 alpha-2
+}
+
+// Invoice holds everything needed to build a single-line ISDOC invoice for
+// billed days.
+type Invoice struct {
+	Number       string
+	UUID         string
+	IssueDate    time.Time
+	TaxPointDate time.Time
+	DueDate      time.Time
+	Currency     string
+	// ExchangeRate is the price of one unit of Currency in CZK, the local
+	// currency of the document; required when Currency is not CZK.
+	ExchangeRate float64
+	Supplier     Party
+	Customer     Party
+
+	Description string
+	Quantity    float64
+	Unit        string
+	UnitPrice   float64
+	VATRate     float64 // percent, 0 for suppliers who are not VAT payers
+
+	IBAN           string
+	BIC            string
+	VariableSymbol string
+	Note           string
+}
+
+// Validate reports missing fields that the ISDOC schema requires.
+func (inv Invoice) Validate() error {
+	var missing []string
+	check := func(value, name string) {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, name)
+		}
+	}
+
+	check(inv.Number, "invoice number")
+	check(inv.UUID, "UUID")
+	check(inv.Currency, "currency")
+	check(inv.Description, "item description")
+	check(inv.Supplier.Name, "supplier name")
+	check(inv.Supplier.ID, "supplier ID")
+	check(inv.Customer.Name, "customer name")
+	check(inv.Customer.ID, "customer ID")
+	if inv.IssueDate.IsZero() {
+		missing = append(missing, "issue date")
+	}
+	if inv.VATRate > 0 && inv.Supplier.VATID == "" {
+		missing = append(missing, "supplier VAT ID")
+	}
+	if inv.foreign() && inv.ExchangeRate == 0 {
+		missing = append(missing, "exchange rate")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("isdoc: missing %s", strings.Join(missing, ", "))
+	}
+	if inv.VATRate < 0 {
+		return fmt.Errorf("isdoc: invalid VAT rate: %v", inv.VATRate)
+	}
+	if inv.ExchangeRate < 0 {
+		return fmt.Errorf("isdoc: invalid exchange rate: %v", inv.ExchangeRate)
+	}
+	return nil
+}
+
+// foreign reports whether the invoice is in a currency other than CZK.
+func (inv Invoice) foreign() bool {
+	return !strings.EqualFold(strings.TrimSpace(inv.Currency), localCurrency)
+}
+
+// Write encodes the invoice as an ISDOC document.
+func Write(w io.Writer, inv Invoice) error {
+	if err := inv.Validate(); err != nil {
+		return err
+	}
+
+	doc, err := newDocument(inv)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// Marshal returns the invoice as an ISDOC document.
+func Marshal(inv Invoice) ([]byte, error) {
+	var sb strings.Builder
+	if err := Write(&sb, inv); err != nil {
+		return nil, err
+	}
+	return []byte(sb.String()), nil
+}
+
+// NewUUID returns a random (version 4) UUID in the upper-case form required
+// by the ISDOC schema.
+func NewUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0F | 0x40
+	b[8] = b[8]&0x3F | 0x80
+	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// The types below mirror the element order of the ISDOC 6 XSD, which is
+// significant: importers reject documents with elements out of sequence.
+
+type document struct {
+	XMLName                                 xml.Name      `xml:"Invoice"`
+	Namespace                               string        `xml:"xmlns,attr"`
+	Version                                 string        `xml:"version,attr"`
+	DocumentType                            int           `xml:"DocumentType"`
+	ID                                      string        `xml:"ID"`
+	UUID                                    string        `xml:"UUID"`
+	IssuingSystem                           string        `xml:"IssuingSystem"`
+	IssueDate                               string        `xml:"IssueDate"`
+	TaxPointDate                            string        `xml:"TaxPointDate,omitempty"`
+	VATApplicable                           bool          `xml:"VATApplicable"`
+	ElectronicPossibilityAgreementReference string        `xml:"ElectronicPossibilityAgreementReference"`
+	Note                                    string        `xml:"Note,omitempty"`
+	LocalCurrencyCode                       string        `xml:"LocalCurrencyCode"`
+	ForeignCurrencyCode                     string        `xml:"ForeignCurrencyCode,omitempty"`
+	CurrRate                                float64       `xml:"CurrRate"`
+	RefCurrRate                             int           `xml:"RefCurrRate"`
+	AccountingSupplierParty                 partyWrapper  `xml:"AccountingSupplierParty"`
+	AccountingCustomerParty                 partyWrapper  `xml:"AccountingCustomerParty"`
+	InvoiceLines                            invoiceLines  `xml:"InvoiceLines"`
+	TaxTotal                                taxTotal      `xml:"TaxTotal"`
+	LegalMonetaryTotal                      legalMonetary `xml:"LegalMonetaryTotal"`
+	PaymentMeans                            *paymentMeans `xml:"PaymentMeans,omitempty"`
+}
+
+type partyWrapper struct {
+	Party party `xml:"Party"`
+}
+
+type party struct {
+	PartyIdentification partyIdentification `xml:"PartyIdentification"`
+	PartyName           partyName           `xml:"PartyName"`
+	PostalAddress       postalAddress       `xml:"PostalAddress"`
+	PartyTaxScheme      *partyTaxScheme     `xml:"PartyTaxScheme,omitempty"`
+}
+
+type partyIdentification struct {
+	ID string `xml:"ID"`
+}
+
+type partyName struct {
+	Name string `xml:"Name"`
+}
+
+type postalAddress struct {
+	StreetName     string  `xml:"StreetName"`
+	BuildingNumber string  `xml:"BuildingNumber"`
+	CityName       string  `xml:"CityName"`
+	PostalZone     string  `xml:"PostalZone"`
+	Country        country `xml:"Country"`
+}
+
+type country struct {
+	IdentificationCode string `xml:"IdentificationCode"`
+	Name               string `xml:"Name"`
+}
+
+type partyTaxScheme struct {
+	CompanyID string `xml:"CompanyID"`
+	TaxScheme string `xml:"TaxScheme"`
+}
+
+type invoiceLines struct {
+	Lines []invoiceLine `xml:"InvoiceLine"`
+}
+
+type invoiceLine struct {
+	ID                                  string        `xml:"ID"`
+	InvoicedQuantity                    quantity      `xml:"InvoicedQuantity"`
+	LineExtensionAmountCurr             *amount       `xml:"LineExtensionAmountCurr,omitempty"`
+	LineExtensionAmount                 amount        `xml:"LineExtensionAmount"`
+	LineExtensionAmountTaxInclusiveCurr *amount       `xml:"LineExtensionAmountTaxInclusiveCurr,omitempty"`
+	LineExtensionAmountTaxInclusive     amount        `xml:"LineExtensionAmountTaxInclusive"`
+	LineExtensionTaxAmount              amount        `xml:"LineExtensionTaxAmount"`
+	UnitPrice                           amount        `xml:"UnitPrice"`
+	UnitPriceTaxInclusive               amount        `xml:"UnitPriceTaxInclusive"`
+	ClassifiedTaxCategory               classifiedTax `xml:"ClassifiedTaxCategory"`
+	Item                                item          `xml:"Item"`
+}
+
+type quantity struct {
+	UnitCode string  `xml:"unitCode,attr,omitempty"`
+	Value    float64 `xml:",chardata"`
+}
+
+type classifiedTax struct {
+	Percent              amount `xml:"Percent"`
+	VATCalculationMethod int    `xml:"VATCalculationMethod"`
+}
+
+type item struct {
+	Description string `xml:"Description"`
+}
+
+type taxTotal struct {
+	TaxSubTotal   taxSubTotal `xml:"TaxSubTotal"`
+	TaxAmountCurr *amount     `xml:"TaxAmountCurr,omitempty"`
+	TaxAmount     amount      `xml:"TaxAmount"`
+}
+
+type taxSubTotal struct {
+	TaxableAmountCurr                    *amount     `xml:"TaxableAmountCurr,omitempty"`
+	TaxableAmount                        amount      `xml:"TaxableAmount"`
+	TaxAmountCurr                        *amount     `xml:"TaxAmountCurr,omitempty"`
+	TaxAmount                            amount      `xml:"TaxAmount"`
+	TaxInclusiveAmountCurr               *amount     `xml:"TaxInclusiveAmountCurr,omitempty"`
+	TaxInclusiveAmount                   amount      `xml:"TaxInclusiveAmount"`
+	AlreadyClaimedTaxableAmountCurr      *amount     `xml:"AlreadyClaimedTaxableAmountCurr,omitempty"`
+	AlreadyClaimedTaxableAmount          amount      `xml:"AlreadyClaimedTaxableAmount"`
+	AlreadyClaimedTaxAmountCurr          *amount     `xml:"AlreadyClaimedTaxAmountCurr,omitempty"`
+	AlreadyClaimedTaxAmount              amount      `xml:"AlreadyClaimedTaxAmount"`
+	AlreadyClaimedTaxInclusiveAmountCurr *amount     `xml:"AlreadyClaimedTaxInclusiveAmountCurr,omitempty"`
+	AlreadyClaimedTaxInclusiveAmount     amount      `xml:"AlreadyClaimedTaxInclusiveAmount"`
+	DifferenceTaxableAmountCurr          *amount     `xml:"DifferenceTaxableAmountCurr,omitempty"`
+	DifferenceTaxableAmount              amount      `xml:"DifferenceTaxableAmount"`
+	DifferenceTaxAmountCurr              *amount     `xml:"DifferenceTaxAmountCurr,omitempty"`
+	DifferenceTaxAmount                  amount      `xml:"DifferenceTaxAmount"`
+	DifferenceTaxInclusiveAmountCurr     *amount     `xml:"DifferenceTaxInclusiveAmountCurr,omitempty"`
+	DifferenceTaxInclusiveAmount         amount      `xml:"DifferenceTaxInclusiveAmount"`
+	TaxCategory                          taxCategory `xml:"TaxCategory"`
+}
+
+type taxCategory struct {
+	Percent amount `xml:"Percent"`
+}
+
+type legalMonetary struct {
+	TaxExclusiveAmount                   amount  `xml:"TaxExclusiveAmount"`
+	TaxExclusiveAmountCurr               *amount `xml:"TaxExclusiveAmountCurr,omitempty"`
+	TaxInclusiveAmount                   amount  `xml:"TaxInclusiveAmount"`
+	TaxInclusiveAmountCurr               *amount `xml:"TaxInclusiveAmountCurr,omitempty"`
+	AlreadyClaimedTaxExclusiveAmount     amount  `xml:"AlreadyClaimedTaxExclusiveAmount"`
+	AlreadyClaimedTaxExclusiveAmountCurr *amount `xml:"AlreadyClaimedTaxExclusiveAmountCurr,omitempty"`
+	AlreadyClaimedTaxInclusiveAmount     amount  `xml:"AlreadyClaimedTaxInclusiveAmount"`
+	AlreadyClaimedTaxInclusiveAmountCurr *amount `xml:"AlreadyClaimedTaxInclusiveAmountCurr,omitempty"`
+	DifferenceTaxExclusiveAmount         amount  `xml:"DifferenceTaxExclusiveAmount"`
+	DifferenceTaxExclusiveAmountCurr     *amount `xml:"DifferenceTaxExclusiveAmountCurr,omitempty"`
+	DifferenceTaxInclusiveAmount         amount  `xml:"DifferenceTaxInclusiveAmount"`
+	DifferenceTaxInclusiveAmountCurr     *amount `xml:"DifferenceTaxInclusiveAmountCurr,omitempty"`
+	PaidDepositsAmount                   amount  `xml:"PaidDepositsAmount"`
+	PaidDepositsAmountCurr               *amount `xml:"PaidDepositsAmountCurr,omitempty"`
+	PayableAmount                        amount  `xml:"PayableAmount"`
+	PayableAmountCurr                    *amount `xml:"PayableAmountCurr,omitempty"`
+}
+
+type paymentMeans struct {
+	Payment payment `xml:"Payment"`
+}
+
+type payment struct {
+	PaidAmount       amount         `xml:"PaidAmount"`
+	PaymentMeansCode int            `xml:"PaymentMeansCode"`
+	Details          paymentDetails `xml:"Details"`
+}
+
+type paymentDetails struct {
+	PaymentDueDate string `xml:"PaymentDueDate,omitempty"`
+	ID             string `xml:"ID"`
+	BankCode       string `xml:"BankCode"`
+	Name           string `xml:"Name"`
+	IBAN           string `xml:"IBAN"`
+	BIC            string `xml:"BIC"`
+	VariableSymbol string `xml:"VariableSymbol,omitempty"`
+}
+
+// amount is a monetary value rendered with exactly two decimals.
+type amount float64
+
+func (a amount) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatFloat(round(float64(a)), 'f', 2, 64)), nil
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// totals are the amounts of the invoice line in one currency.
+type totals struct {
+	net, tax, gross float64
+}
+
+func newTotals(net, vatRate float64) totals {
+	net = round(net)
+	tax := round(net * vatRate / 100)
+	return totals{net: net, tax: tax, gross: net + tax}
+}
+
+// newDocument builds the document of an invoice. Its amounts are in
+// CZK; an invoice in a foreign currency is converted at its exchange rate
+// and also carries the amounts in that currency in the *Curr elements, with
+// the VAT computed from the converted base as Czech law requires.
+func newDocument(inv Invoice) (document, error) {
+	local := newTotals(inv.Quantity*inv.UnitPrice, inv.VATRate)
+	unitPrice := inv.UnitPrice
+	rate := 1.0
+	var foreign totals
+	var foreignCode string
+	if inv.foreign() {
+		foreign = local
+		rate = inv.ExchangeRate
+		local = newTotals(foreign.net*rate, inv.VATRate)
+		unitPrice = round(inv.UnitPrice * rate)
+		foreignCode = strings.ToUpper(strings.TrimSpace(inv.Currency))
+	}
+	// curr returns a foreign-currency amount, or nil to leave out its
+	// element on an invoice in CZK.
+	curr := func(value float64) *amount {
+		if foreignCode == "" {
+			return nil
+		}
+		a := amount(value)
+		return &a
+	}
+
+	doc := document{
+		Namespace:               Namespace,
+		Version:                 Version,
+		DocumentType:            documentTypeInvoice,
+		ID:                      inv.Number,
+		UUID:                    inv.UUID,
+		IssuingSystem:           "billme",
+		IssueDate:               formatDate(inv.IssueDate),
+		TaxPointDate:            formatDate(inv.TaxPointDate),
+		VATApplicable:           inv.VATRate > 0,
+		Note:                    inv.Note,
+		LocalCurrencyCode:       localCurrency,
+		ForeignCurrencyCode:     foreignCode,
+		CurrRate:                rate,
+		RefCurrRate:             1,
+		AccountingSupplierParty: partyWrapper{newParty(inv.Supplier)},
+		AccountingCustomerParty: partyWrapper{newParty(inv.Customer)},
+		InvoiceLines: invoiceLines{[]invoiceLine{{
+			ID:                                  "1",
+			InvoicedQuantity:                    quantity{UnitCode: inv.Unit, Value: inv.Quantity},
+			LineExtensionAmountCurr:             curr(foreign.net),
+			LineExtensionAmount:                 amount(local.net),
+			LineExtensionAmountTaxInclusiveCurr: curr(foreign.gross),
+			LineExtensionAmountTaxInclusive:     amount(local.gross),
+			LineExtensionTaxAmount:              amount(local.tax),
+			UnitPrice:                           amount(unitPrice),
+			UnitPriceTaxInclusive:               amount(round(unitPrice * (1 + inv.VATRate/100))),
+			ClassifiedTaxCategory:               classifiedTax{Percent: amount(inv.VATRate)},
+			Item:                                item{Description: inv.Description},
+		}}},
+		TaxTotal: taxTotal{
+			TaxSubTotal: taxSubTotal{
+				TaxableAmountCurr:                    curr(foreign.net),
+				TaxableAmount:                        amount(local.net),
+				TaxAmountCurr:                        curr(foreign.tax),
+				TaxAmount:                            amount(local.tax),
+				TaxInclusiveAmountCurr:               curr(foreign.gross),
+				TaxInclusiveAmount:                   amount(local.gross),
+				AlreadyClaimedTaxableAmountCurr:      curr(0),
+				AlreadyClaimedTaxAmountCurr:          curr(0),
+				AlreadyClaimedTaxInclusiveAmountCurr: curr(0),
+				DifferenceTaxableAmountCurr:          curr(foreign.net),
+				DifferenceTaxableAmount:              amount(local.net),
+				DifferenceTaxAmountCurr:              curr(foreign.tax),
+				DifferenceTaxAmount:                  amount(local.tax),
+				DifferenceTaxInclusiveAmountCurr:     curr(foreign.gross),
+				DifferenceTaxInclusiveAmount:         amount(local.gross),
+				TaxCategory:                          taxCategory{Percent: amount(inv.VATRate)},
+			},
+			TaxAmountCurr: curr(foreign.tax),
+			TaxAmount:     amount(local.tax),
+		},
+		LegalMonetaryTotal: legalMonetary{
+			TaxExclusiveAmount:                   amount(local.net),
+			TaxExclusiveAmountCurr:               curr(foreign.net),
+			TaxInclusiveAmount:                   amount(local.gross),
+			TaxInclusiveAmountCurr:               curr(foreign.gross),
+			AlreadyClaimedTaxExclusiveAmountCurr: curr(0),
+			AlreadyClaimedTaxInclusiveAmountCurr: curr(0),
+			DifferenceTaxExclusiveAmount:         amount(local.net),
+			DifferenceTaxExclusiveAmountCurr:     curr(foreign.net),
+			DifferenceTaxInclusiveAmount:         amount(local.gross),
+			DifferenceTaxInclusiveAmountCurr:     curr(foreign.gross),
+			PaidDepositsAmountCurr:               curr(0),
+			PayableAmount:                        amount(local.gross),
+			PayableAmountCurr:                    curr(foreign.gross),
+		},
+	}
+
+	if inv.IBAN != "" {
+		account, bankCode, err := domesticAccount(inv.IBAN)
+		if err != nil {
+			return document{}, fmt.Errorf("isdoc: %v: %s", err, inv.IBAN)
+		}
+		doc.PaymentMeans = &paymentMeans{payment{
+			PaidAmount:       amount(local.gross),
+			PaymentMeansCode: paymentBankTransfer,
+			Details: paymentDetails{
+				PaymentDueDate: formatDate(inv.DueDate),
+				ID:             account,
+				BankCode:       bankCode,
+				Name:           bankNames[bankCode],
+				IBAN:           strings.ToUpper(strings.ReplaceAll(inv.IBAN, " ", "")),
+				BIC:            strings.ToUpper(inv.BIC),
+				VariableSymbol: inv.VariableSymbol,
+			},
+		}}
+	}
+
+	return doc, nil
+}
+
+func newParty(p Party) party {
+	code := strings.ToUpper(p.Country)
+	if code == "" {
+		code = "CZ"
+	}
+
+	result := party{
+		PartyIdentification: partyIdentification{ID: p.ID},
+		PartyName:           partyName{Name: p.Name},
+		PostalAddress: postalAddress{
+			StreetName:     p.Street,
+			BuildingNumber: p.BuildingNumber,
+			CityName:       p.City,
+			PostalZone:     p.PostalCode,
+			Country:        country{IdentificationCode: code, Name: countryName(code)},
+		},
+	}
+	if p.VATID != "" {
+		result.PartyTaxScheme = &partyTaxScheme{CompanyID: p.VATID, TaxScheme: "VAT"}
+	}
+	return result
+}
+
+// bankNames maps the most common Czech bank codes to the bank names shown
+// in the payment details.
+var bankNames = map[string]string{
+	"0100": "Komerční banka",
+	"0300": "ČSOB",
+	"0600": "MONETA Money Bank",
+	"0800": "Česká spořitelna",
+	"2010": "Fio banka",
+	"2700": "UniCredit Bank",
+	"3030": "Air Bank",
+	"5500": "Raiffeisenbank",
+	"6210": "mBank",
+}
+
+var countryNames = map[string]string{
+	"CZ": "Česká republika",
+	"SK": "Slovensko",
+	"DE": "Německo",
+	"AT": "Rakousko",
+	"PL": "Polsko",
+}
+
+func countryName(code string) string {
+	if name, ok := countryNames[code]; ok {
+		return name
+	}
+	return code
+}
+
+// domesticAccount splits a Czech or Slovak IBAN into the local account
+// number (prefix-number) and bank code that ISDOC expects alongside it.
+func domesticAccount(iban string) (string, string, error) {
+	iban = strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+	if len(iban) != 24 || (iban[:2] != "CZ" && iban[:2] != "SK") {
+		return "", "", errors.New("not a Czech or Slovak IBAN")
+	}
+
+	bankCode := iban[4:8]
+	prefix := strings.TrimLeft(iban[8:14], "0")
+	number := strings.TrimLeft(iban[14:], "0")
+	if prefix != "" {
+		return prefix + "-" + number, bankCode, nil
+	}
+	return number, bankCode, nil
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,398 @@
+package isdoc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleInvoice() Invoice {
+	return Invoice{
+		Number:       "20240007",
+		UUID:         "6F9619FF-8B86-4011-B42D-00C04FC964FF",
+		IssueDate:    time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC),
+		TaxPointDate: time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC),
+		DueDate:      time.Date(2024, 8, 14, 0, 0, 0, 0, time.UTC),
+		Currency:     "CZK",
+		Supplier: Party{
+			ID: "12345678", VATID: "CZ12345678", Name: "Jan Novák",
+			Street: "Dlouhá", BuildingNumber: "12", City: "Praha", PostalCode: "11000", Country: "CZ",
+		},
+		Customer: Party{
+			ID: "87654321", Name: "ACME s.r.o.",
+			Street: "Krátká", BuildingNumber: "3", City: "Brno", PostalCode: "60200",
+		},
+		Description:    "Programming services 07/2024",
+		Quantity:       22,
+		Unit:           "DAY",
+		UnitPrice:      6000,
+		VATRate:        21,
+		IBAN:           "CZ6508000000192000145399",
+		VariableSymbol: "20240007",
+	}
+}
+
+// schema lists the child element sequences of the ISDOC 6.0.2 XSD for the
+// elements billme produces. Optional elements are included so relative
+// order is checked too; required ones are marked.
+type child struct {
+	name     string
+	required bool
+}
+
+var schema = map[string][]child{
+	"Invoice": {
+		{"DocumentType", true}, {"SubDocumentType", false}, {"SubDocumentTypeOrigin", false},
+		{"TargetConsolidator", false}, {"ClientOnTargetConsolidator", false}, {"ClientBankAccount", false},
+		{"ID", true}, {"UUID", true}, {"EgovFlag", false}, {"ISDSID", false}, {"FileReference", false},
+		{"ReferenceNumber", false}, {"EgovClassifiers", false}, {"IssuingSystem", false},
+		{"IssueDate", true}, {"TaxPointDate", false}, {"VATApplicable", true},
+		{"ElectronicPossibilityAgreementReference", true}, {"Note", false},
+		{"LocalCurrencyCode", true}, {"ForeignCurrencyCode", false}, {"CurrRate", true}, {"RefCurrRate", true},
+		{"Extensions", false}, {"AccountingSupplierParty", true}, {"SellerSupplierParty", false},
+		{"AnonymousCustomerParty", false}, {"AccountingCustomerParty", false}, {"BuyerCustomerParty", false},
+		{"OrderReferences", false}, {"DeliveryNoteReferences", false}, {"OriginalDocumentReferences", false},
+		{"ContractReferences", false}, {"Delivery", false}, {"InvoiceLines", true},
+		{"NonTaxedDeposits", false}, {"TaxedDeposits", false}, {"TaxTotal", true},
+		{"LegalMonetaryTotal", true}, {"PaymentMeans", false}, {"SupplementsList", false},
+	},
+	"Party": {
+		{"PartyIdentification", true}, {"PartyName", true}, {"PostalAddress", true},
+		{"PartyTaxScheme", false}, {"RegisterIdentification", false}, {"Contact", false},
+	},
+	"PostalAddress": {
+		{"StreetName", true}, {"BuildingNumber", true}, {"CityName", true}, {"PostalZone", true}, {"Country", true},
+	},
+	"Country":        {{"IdentificationCode", true}, {"Name", true}},
+	"PartyTaxScheme": {{"CompanyID", true}, {"TaxScheme", true}},
+	"InvoiceLine": {
+		{"ID", true}, {"OrderReference", false}, {"DeliveryNoteReference", false},
+		{"OriginalDocumentReference", false}, {"ContractReference", false}, {"EgovClassifiers", false},
+		{"InvoicedQuantity", false}, {"LineExtensionAmountCurr", false}, {"LineExtensionAmount", true},
+		{"LineExtensionAmountBeforeDiscount", false}, {"LineExtensionAmountTaxInclusiveCurr", false},
+		{"LineExtensionAmountTaxInclusive", true}, {"LineExtensionAmountTaxInclusiveBeforeDiscount", false},
+		{"LineExtensionTaxAmount", true}, {"UnitPrice", true}, {"UnitPriceTaxInclusive", true},
+		{"ClassifiedTaxCategory", true}, {"Note", false}, {"VATNote", false}, {"Item", false},
+	},
+	"ClassifiedTaxCategory": {
+		{"Percent", true}, {"VATCalculationMethod", true}, {"VATApplicable", false}, {"LocalReverseChargeFlag", false},
+	},
+	"TaxTotal": {{"TaxSubTotal", true}, {"TaxAmountCurr", false}, {"TaxAmount", true}},
+	"TaxSubTotal": {
+		{"TaxableAmountCurr", false}, {"TaxableAmount", true}, {"TaxAmountCurr", false}, {"TaxAmount", true},
+		{"TaxInclusiveAmountCurr", false}, {"TaxInclusiveAmount", true},
+		{"AlreadyClaimedTaxableAmountCurr", false}, {"AlreadyClaimedTaxableAmount", true},
+		{"AlreadyClaimedTaxAmountCurr", false}, {"AlreadyClaimedTaxAmount", true},
+		{"AlreadyClaimedTaxInclusiveAmountCurr", false}, {"AlreadyClaimedTaxInclusiveAmount", true},
+		{"DifferenceTaxableAmountCurr", false}, {"DifferenceTaxableAmount", true},
+		{"DifferenceTaxAmountCurr", false}, {"DifferenceTaxAmount", true},
+		{"DifferenceTaxInclusiveAmountCurr", false}, {"DifferenceTaxInclusiveAmount", true},
+		{"TaxCategory", true},
+	},
+	"LegalMonetaryTotal": {
+		{"TaxExclusiveAmount", true}, {"TaxExclusiveAmountCurr", false},
+		{"TaxInclusiveAmount", true}, {"TaxInclusiveAmountCurr", false},
+		{"AlreadyClaimedTaxExclusiveAmount", true}, {"AlreadyClaimedTaxExclusiveAmountCurr", false},
+		{"AlreadyClaimedTaxInclusiveAmount", true}, {"AlreadyClaimedTaxInclusiveAmountCurr", false},
+		{"DifferenceTaxExclusiveAmount", true}, {"DifferenceTaxExclusiveAmountCurr", false},
+		{"DifferenceTaxInclusiveAmount", true}, {"DifferenceTaxInclusiveAmountCurr", false},
+		{"PayableRoundingAmount", false}, {"PayableRoundingAmountCurr", false},
+		{"PaidDepositsAmount", true}, {"PaidDepositsAmountCurr", false},
+		{"PayableAmount", true}, {"PayableAmountCurr", false},
+	},
+	"Payment": {{"PaidAmount", true}, {"PaymentMeansCode", true}, {"Details", false}},
+	"Details": {
+		{"PaymentDueDate", false}, {"ID", true}, {"BankCode", true}, {"Name", true}, {"IBAN", true},
+		{"BIC", true}, {"VariableSymbol", false}, {"ConstantSymbol", false}, {"SpecificSymbol", false},
+	},
+}
+
+type node struct {
+	name     xml.Name
+	children []*node
+	text     string
+}
+
+func parse(t *testing.T, data []byte) *node {
+	t.Helper()
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var stack []*node
+	var root *node
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("output is not well-formed XML: %v", err)
+		}
+		switch tok := token.(type) {
+		case xml.StartElement:
+			n := &node{name: tok.Name}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += strings.TrimSpace(string(tok))
+			}
+		}
+	}
+	return root
+}
+
+func validate(t *testing.T, n *node, path string) {
+	t.Helper()
+	path += "/" + n.name.Local
+	if n.name.Space != Namespace {
+		t.Errorf("%s: namespace %q; want %q", path, n.name.Space, Namespace)
+	}
+
+	if sequence, ok := schema[n.name.Local]; ok {
+		pos := 0
+		seen := map[string]bool{}
+		for _, c := range n.children {
+			for pos < len(sequence) && sequence[pos].name != c.name.Local {
+				pos++
+			}
+			if pos == len(sequence) {
+				t.Errorf("%s: unexpected or out of order element %s", path, c.name.Local)
+				break
+			}
+			seen[c.name.Local] = true
+		}
+		for _, c := range sequence {
+			if c.required && !seen[c.name] {
+				t.Errorf("%s: missing required element %s", path, c.name)
+			}
+		}
+	}
+
+	for _, c := range n.children {
+		validate(t, c, path)
+	}
+}
+
+func find(n *node, path ...string) *node {
+	for _, name := range path {
+		var next *node
+		for _, c := range n.children {
+			if c.name.Local == name {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		n = next
+	}
+	return n
+}
+
+func TestMarshalMatchesSchema(t *testing.T) {
+	data, err := Marshal(sampleInvoice())
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+
+	if !bytes.HasPrefix(data, []byte(xml.Header)) {
+		t.Error("document should start with the XML declaration")
+	}
+	if !bytes.Contains(data, []byte(`version="6.0.2"`)) {
+		t.Error("document should declare ISDOC version 6.0.2")
+	}
+
+	root := parse(t, data)
+	if root.name.Local != "Invoice" {
+		t.Fatalf("root element %s; want Invoice", root.name.Local)
+	}
+	validate(t, root, "")
+}
+
+func TestMarshalAmounts(t *testing.T) {
+	data, err := Marshal(sampleInvoice())
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	root := parse(t, data)
+
+	tests := []struct {
+		path     []string
+		expected string
+	}{
+		{[]string{"InvoiceLines", "InvoiceLine", "InvoicedQuantity"}, "22"},
+		{[]string{"InvoiceLines", "InvoiceLine", "LineExtensionAmount"}, "132000.00"},
+		{[]string{"InvoiceLines", "InvoiceLine", "LineExtensionTaxAmount"}, "27720.00"},
+		{[]string{"InvoiceLines", "InvoiceLine", "UnitPriceTaxInclusive"}, "7260.00"},
+		{[]string{"TaxTotal", "TaxAmount"}, "27720.00"},
+		{[]string{"LegalMonetaryTotal", "PayableAmount"}, "159720.00"},
+		{[]string{"VATApplicable"}, "true"},
+		{[]string{"PaymentMeans", "Payment", "Details", "ID"}, "19-2000145399"},
+		{[]string{"PaymentMeans", "Payment", "Details", "BankCode"}, "0800"},
+		{[]string{"PaymentMeans", "Payment", "Details", "PaymentDueDate"}, "2024-08-14"},
+		{[]string{"AccountingCustomerParty", "Party", "PostalAddress", "Country", "IdentificationCode"}, "CZ"},
+	}
+
+	for _, tt := range tests {
+		n := find(root, tt.path...)
+		if n == nil {
+			t.Errorf("%s: element not found", strings.Join(tt.path, "/"))
+			continue
+		}
+		if n.text != tt.expected {
+			t.Errorf("%s = %q; want %q", strings.Join(tt.path, "/"), n.text, tt.expected)
+		}
+	}
+}
+
+func TestMarshalNonVATPayer(t *testing.T) {
+	inv := sampleInvoice()
+	inv.VATRate = 0
+	inv.Supplier.VATID = ""
+
+	data, err := Marshal(inv)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	root := parse(t, data)
+	validate(t, root, "")
+
+	if n := find(root, "VATApplicable"); n == nil || n.text != "false" {
+		t.Error("VATApplicable should be false for a non-VAT payer")
+	}
+	if n := find(root, "LegalMonetaryTotal", "PayableAmount"); n == nil || n.text != "132000.00" {
+		t.Error("PayableAmount should equal the net amount for a non-VAT payer")
+	}
+	if find(root, "AccountingSupplierParty", "Party", "PartyTaxScheme") != nil {
+		t.Error("PartyTaxScheme should be omitted without a VAT ID")
+	}
+}
+
+func TestMarshalForeignCurrency(t *testing.T) {
+	inv := sampleInvoice()
+	inv.Currency = "eur"
+	inv.ExchangeRate = 25.125
+	inv.UnitPrice = 250
+
+	data, err := Marshal(inv)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	root := parse(t, data)
+	validate(t, root, "")
+
+	tests := []struct {
+		path     []string
+		expected string
+	}{
+		{[]string{"LocalCurrencyCode"}, "CZK"},
+		{[]string{"ForeignCurrencyCode"}, "EUR"},
+		{[]string{"CurrRate"}, "25.125"},
+		{[]string{"RefCurrRate"}, "1"},
+		{[]string{"InvoiceLines", "InvoiceLine", "LineExtensionAmountCurr"}, "5500.00"},
+		{[]string{"InvoiceLines", "InvoiceLine", "LineExtensionAmount"}, "138187.50"},
+		{[]string{"InvoiceLines", "InvoiceLine", "LineExtensionTaxAmount"}, "29019.38"},
+		{[]string{"InvoiceLines", "InvoiceLine", "UnitPrice"}, "6281.25"},
+		{[]string{"TaxTotal", "TaxAmountCurr"}, "1155.00"},
+		{[]string{"TaxTotal", "TaxAmount"}, "29019.38"},
+		{[]string{"LegalMonetaryTotal", "PayableAmount"}, "167206.88"},
+		{[]string{"LegalMonetaryTotal", "PayableAmountCurr"}, "6655.00"},
+	}
+	for _, tt := range tests {
+		n := find(root, tt.path...)
+		if n == nil {
+			t.Errorf("%s: element not found", strings.Join(tt.path, "/"))
+			continue
+		}
+		if n.text != tt.expected {
+			t.Errorf("%s = %q; want %q", strings.Join(tt.path, "/"), n.text, tt.expected)
+		}
+	}
+}
+
+func TestMarshalLocalCurrency(t *testing.T) {
+	data, err := Marshal(sampleInvoice())
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %v", err)
+	}
+	root := parse(t, data)
+
+	if find(root, "ForeignCurrencyCode") != nil || find(root, "LegalMonetaryTotal", "PayableAmountCurr") != nil {
+		t.Error("an invoice in CZK should have no foreign-currency elements")
+	}
+	if n := find(root, "CurrRate"); n == nil || n.text != "1" {
+		t.Error("CurrRate should be 1 for an invoice in CZK")
+	}
+}
+
+func TestMarshalForeignIBAN(t *testing.T) {
+	inv := sampleInvoice()
+	inv.IBAN = "DE89370400440532013000"
+
+	if _, err := Marshal(inv); err == nil || !strings.Contains(err.Error(), "DE89370400440532013000") {
+		t.Errorf("Marshal() error = %v; want one rejecting the foreign IBAN", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	inv := sampleInvoice()
+	inv.Number = ""
+	inv.Customer.ID = ""
+
+	err := inv.Validate()
+	if err == nil {
+		t.Fatal("Validate() should return error for missing fields")
+	}
+	for _, field := range []string{"invoice number", "customer ID"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error %q should mention %s", err, field)
+		}
+	}
+
+	inv = sampleInvoice()
+	inv.Supplier.VATID = ""
+	if inv.Validate() == nil {
+		t.Error("Validate() should require a supplier VAT ID when VAT applies")
+	}
+
+	inv = sampleInvoice()
+	inv.Currency = "EUR"
+	if err := inv.Validate(); err == nil || !strings.Contains(err.Error(), "exchange rate") {
+		t.Errorf("Validate() error = %v; want one requiring an exchange rate for EUR", err)
+	}
+}
+
+func TestNewUUID(t *testing.T) {
+	uuid, err := NewUUID()
+	if err != nil {
+		t.Fatalf("NewUUID() returned error: %v", err)
+	}
+
+	if len(uuid) != 36 || strings.ToUpper(uuid) != uuid || uuid[14] != '4' {
+		t.Errorf("NewUUID() = %q; want upper-case version 4 UUID", uuid)
+	}
+}
+
+func TestDomesticAccount(t *testing.T) {
+	account, bankCode, err := domesticAccount("CZ65 0800 0000 1920 0014 5399")
+	if err != nil {
+		t.Fatalf("domesticAccount() returned error: %v", err)
+	}
+	if account != "19-2000145399" || bankCode != "0800" {
+		t.Errorf("domesticAccount() = %s/%s; want 19-2000145399/0800", account, bankCode)
+	}
+
+	if _, _, err := domesticAccount("DE89370400440532013000"); err == nil {
+		t.Error("domesticAccount() should reject non-Czech IBANs")
+	}
+}
@@ -0,0 +1,335 @@
+package numbering
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPattern numbers invoices by year with a four digit counter,
+// e.g. 20240001.
+const DefaultPattern = "{YYYY}{NNNN}"
+
+const (
+	StatusIssued = "issued"
+	StatusVoid   = "void"
+)
+
+var (
+	ErrNotFound    = errors.New("invoice number not found")
+	ErrLocked      = errors.New("numbering state is locked by another billme process")
+	ErrInvalidStep = errors.New("invalid status change")
+)
+
+var counterToken = regexp.MustCompile(`\{N+\}`)
+
+// Format expands pattern for the given counter value. Supported tokens are
+// {YYYY}, {YY}, {MM}, {PREFIX} and {N…}, where the number of Ns sets the
+// zero-padded width of the counter.
+func Format(pattern, prefix string, date time.Time, counter int) (string, error) {
+	series, err := seriesKey(pattern, prefix, date)
+	if err != nil {
+		return "", err
+	}
+	token := counterToken.FindString(pattern)
+	width := len(token) - 2
+	return strings.Replace(series, token, fmt.Sprintf("%0*d", width, counter), 1), nil
+}
+
+// seriesKey expands every token except the counter. Numbers sharing a series
+// key share a counter, so a pattern with {YYYY} restarts every year.
+func seriesKey(pattern, prefix string, date time.Time) (string, error) {
+	if len(counterToken.FindAllString(pattern, -1)) != 1 {
+		return "", fmt.Errorf("invalid numbering pattern %q: exactly one {N…} counter required", pattern)
+	}
+	if strings.Contains(pattern, "{PREFIX}") && prefix == "" {
+		return "", fmt.Errorf("numbering pattern %q needs a client prefix", pattern)
+	}
+
+	replacer := strings.NewReplacer(
+		"{YYYY}", fmt.Sprintf("%04d", date.Year()),
+		"{YY}", fmt.Sprintf("%02d", date.Year()%100),
+		"{MM}", fmt.Sprintf("%02d", int(date.Month())),
+		"{PREFIX}", prefix,
+	)
+	result := replacer.Replace(pattern)
+
+	if rest := counterToken.ReplaceAllString(result, ""); strings.ContainsAny(rest, "{}") {
+		return "", fmt.Errorf("invalid numbering pattern %q: unknown token", pattern)
+	}
+	return result, nil
+}
+
+// Entry is an issued invoice number.
+type Entry struct {
+	Number   string    `json:"number"`
+	Series   string    `json:"series"`
+	Client   string    `json:"client,omitempty"`
+	Status   string    `json:"status"`
+	IssuedAt time.Time `json:"issued_at"`
+}
+
+// Event is a line of the audit trail.
+type Event struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	Number string    `json:"number"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+type state struct {
+	Counters map[string]int `json:"counters"`
+	Entries  []Entry        `json:"entries"`
+	Audit    []Event        `json:"audit"`
+}
+
+// Store keeps numbering state in a JSON file. Every operation holds an
+// exclusive operating system lock on a lock file next to it, so concurrent
+// billme runs never hand out the same number. The lock goes with the
+// process that holds it, so a crashed run never leaves the state locked.
+type Store struct {
+	Path string
+	// LockTimeout is how long to wait for another process to release the
+	// lock.
+	LockTimeout time.Duration
+	// Now returns the time recorded in entries and the audit trail.
+	Now func() time.Time
+}
+
+// DefaultPath returns the numbering state file in the user's configuration
+// directory, next to the config file.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "billme", "invoice-numbers.json")
+}
+
+// NewStore returns a store for the state file at path.
+func NewStore(path string) *Store {
+	return &Store{Path: path, LockTimeout: 10 * time.Second, Now: time.Now}
+}
+
+// Next issues the next number of the series given by pattern, prefix and
+// date, and records it for client.
+func (s *Store) Next(pattern, prefix, client string, date time.Time) (string, error) {
+	if pattern == "" {
+		pattern = DefaultPattern
+	}
+	series, err := seriesKey(pattern, prefix, date)
+	if err != nil {
+		return "", err
+	}
+
+	var number string
+	err = s.update(func(st *state) error {
+		counter := st.Counters[series] + 1
+		number, err = Format(pattern, prefix, date, counter)
+		if err != nil {
+			return err
+		}
+		for _, e := range st.Entries {
+			if e.Number == number {
+				return fmt.Errorf("invoice number %s was already issued", number)
+			}
+		}
+
+		st.Counters[series] = counter
+		st.Entries = append(st.Entries, Entry{
+			Number: number, Series: series, Client: client, Status: StatusIssued, IssuedAt: s.Now(),
+		})
+		st.Audit = append(st.Audit, Event{Time: s.Now(), Action: "issue", Number: number})
+		return nil
+	})
+	return number, err
+}
+
+// Void marks an issued number as void. The number stays used so the series
+// remains continuous; the reason is kept in the audit trail.
+func (s *Store) Void(number, reason string) error {
+	return s.changeStatus(number, StatusIssued, StatusVoid, "void", reason)
+}
+
+// Reissue puts a voided number back into use, e.g. for a corrected invoice.
+func (s *Store) Reissue(number, reason string) error {
+	return s.changeStatus(number, StatusVoid, StatusIssued, "reissue", reason)
+}
+
+// Release takes back an issued number that was never used, e.g. because its
+// invoice could not be written. The latest number of its series goes back
+// to the counter to be issued again; an earlier one is voided instead, so
+// that the series stays continuous.
+func (s *Store) Release(number, reason string) error {
+	return s.update(func(st *state) error {
+		for i, entry := range st.Entries {
+			if entry.Number != number {
+				continue
+			}
+			if entry.Status != StatusIssued {
+				return fmt.Errorf("%w: %s is %s", ErrInvalidStep, number, entry.Status)
+			}
+			for _, later := range st.Entries[i+1:] {
+				if later.Series == entry.Series {
+					st.Entries[i].Status = StatusVoid
+					st.Audit = append(st.Audit, Event{Time: s.Now(), Action: "void", Number: number, Reason: reason})
+					return nil
+				}
+			}
+			st.Entries = append(st.Entries[:i], st.Entries[i+1:]...)
+			st.Counters[entry.Series]--
+			st.Audit = append(st.Audit, Event{Time: s.Now(), Action: "release", Number: number, Reason: reason})
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrNotFound, number)
+	})
+}
+
+func (s *Store) changeStatus(number, from, to, action, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("a reason is required to %s %s", action, number)
+	}
+
+	return s.update(func(st *state) error {
+		for i := range st.Entries {
+			entry := &st.Entries[i]
+			if entry.Number != number {
+				continue
+			}
+			if entry.Status != from {
+				return fmt.Errorf("%w: %s is %s", ErrInvalidStep, number, entry.Status)
+			}
+			entry.Status = to
+			st.Audit = append(st.Audit, Event{Time: s.Now(), Action: action, Number: number, Reason: reason})
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrNotFound, number)
+	})
+}
+
+// Entries returns all issued numbers in the order they were issued.
+func (s *Store) Entries() ([]Entry, error) {
+	st, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return st.Entries, nil
+}
+
+// Audit returns the audit trail, oldest first.
+func (s *Store) Audit() ([]Event, error) {
+	st, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return st.Audit, nil
+}
+
+func (s *Store) read() (*state, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.load()
+}
+
+// update runs fn on the state under the lock and saves the result unless fn
+// returns an error.
+func (s *Store) update(fn func(*state) error) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	st, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := fn(st); err != nil {
+		return err
+	}
+	return s.save(st)
+}
+
+func (s *Store) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o755); err != nil {
+		return nil, err
+	}
+
+	// The lock file is never removed: a process removing it could let
+	// another lock a new file while a third still holds the old one.
+	lockPath := s.Path + ".lock"
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(s.LockTimeout)
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("locking %s: %w", lockPath, err)
+		}
+		if locked {
+			return func() {
+				unlock(file)
+				file.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, fmt.Errorf("%w: %s", ErrLocked, lockPath)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func (s *Store) load() (*state, error) {
+	st := &state{Counters: map[string]int{}}
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("parsing numbering state %s: %w", s.Path, err)
+	}
+	if st.Counters == nil {
+		st.Counters = map[string]int{}
+	}
+	return st, nil
+}
+
+// save writes the state to a temporary file and renames it over the old one
+// so a crash never leaves a truncated state file behind.
+func (s *Store) save(st *state) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.Path + ".tmp." + strconv.Itoa(os.Getpid())
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.Path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
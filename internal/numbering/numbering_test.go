@@ -0,0 +1,267 @@
+package numbering
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	store := NewStore(filepath.Join(t.TempDir(), "numbers.json"))
+	store.Now = func() time.Time { return time.Date(2024, 7, 31, 12, 0, 0, 0, time.UTC) }
+	return store
+}
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		pattern  string
+		prefix   string
+		expected string
+	}{
+		{"{YYYY}{NNNN}", "", "20240007"},
+		{"{PREFIX}-{YY}{MM}-{NNN}", "AC", "AC-2407-007"},
+		{"FV{YYYY}/{N}", "", "FV2024/7"},
+	}
+
+	for _, tt := range tests {
+		result, err := Format(tt.pattern, tt.prefix, date, 7)
+		if err != nil {
+			t.Errorf("Format(%q) returned error: %v", tt.pattern, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("Format(%q) = %q; want %q", tt.pattern, result, tt.expected)
+		}
+	}
+}
+
+func TestFormatInvalidPattern(t *testing.T) {
+	date := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		pattern string
+		prefix  string
+	}{
+		{"{YYYY}", ""},
+		{"{NNN}{NNN}", ""},
+		{"{YYYY}{DD}{NNN}", ""},
+		{"{PREFIX}{NNNN}", ""},
+	}
+
+	for _, tt := range tests {
+		if _, err := Format(tt.pattern, tt.prefix, date, 1); err == nil {
+			t.Errorf("Format(%q, %q) should return error", tt.pattern, tt.prefix)
+		}
+	}
+}
+
+func TestNextSequence(t *testing.T) {
+	store := newTestStore(t)
+	july := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	expected := []string{"20240001", "20240002", "20240003"}
+	for _, want := range expected {
+		got, err := store.Next("", "", "", july)
+		if err != nil {
+			t.Fatalf("Next() returned error: %v", err)
+		}
+		if got != want {
+			t.Errorf("Next() = %q; want %q", got, want)
+		}
+	}
+
+	// A new year starts a new series.
+	got, err := store.Next("", "", "", time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Next() returned error: %v", err)
+	}
+	if got != "20250001" {
+		t.Errorf("Next() in new year = %q; want 20250001", got)
+	}
+}
+
+func TestNextPerClientPrefix(t *testing.T) {
+	store := newTestStore(t)
+	date := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	pattern := "{PREFIX}{YYYY}{NNN}"
+
+	a1, _ := store.Next(pattern, "AC", "acme", date)
+	b1, _ := store.Next(pattern, "GL", "globex", date)
+	a2, _ := store.Next(pattern, "AC", "acme", date)
+
+	if a1 != "AC2024001" || a2 != "AC2024002" || b1 != "GL2024001" {
+		t.Errorf("Next() = %s, %s, %s; want AC2024001, AC2024002, GL2024001", a1, a2, b1)
+	}
+
+	entries, err := store.Entries()
+	if err != nil {
+		t.Fatalf("Entries() returned error: %v", err)
+	}
+	if len(entries) != 3 || entries[1].Client != "globex" {
+		t.Errorf("Entries() = %+v; want three entries with clients recorded", entries)
+	}
+}
+
+func TestNextConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "numbers.json")
+	date := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	const runs = 20
+	results := make(chan string, runs)
+	var wg sync.WaitGroup
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			number, err := NewStore(path).Next("", "", "", date)
+			if err != nil {
+				t.Errorf("Next() returned error: %v", err)
+				return
+			}
+			results <- number
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	seen := map[string]bool{}
+	for number := range results {
+		if seen[number] {
+			t.Errorf("number %s issued twice", number)
+		}
+		seen[number] = true
+	}
+	if len(seen) != runs {
+		t.Errorf("issued %d distinct numbers; want %d", len(seen), runs)
+	}
+}
+
+func TestVoidAndReissue(t *testing.T) {
+	store := newTestStore(t)
+	date := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	number, _ := store.Next("", "", "", date)
+
+	if err := store.Void(number, ""); err == nil {
+		t.Error("Void() should require a reason")
+	}
+	if err := store.Reissue(number, "not void"); !errors.Is(err, ErrInvalidStep) {
+		t.Errorf("Reissue() of an issued number error = %v; want ErrInvalidStep", err)
+	}
+	if err := store.Void(number, "wrong amount"); err != nil {
+		t.Fatalf("Void() returned error: %v", err)
+	}
+	if err := store.Void(number, "again"); !errors.Is(err, ErrInvalidStep) {
+		t.Errorf("Void() twice error = %v; want ErrInvalidStep", err)
+	}
+	if err := store.Void("20249999", "typo"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Void() unknown number error = %v; want ErrNotFound", err)
+	}
+
+	// A voided number is never handed out again.
+	next, _ := store.Next("", "", "", date)
+	if next != "20240002" {
+		t.Errorf("Next() after void = %q; want 20240002", next)
+	}
+
+	if err := store.Reissue(number, "corrected invoice"); err != nil {
+		t.Fatalf("Reissue() returned error: %v", err)
+	}
+
+	audit, err := store.Audit()
+	if err != nil {
+		t.Fatalf("Audit() returned error: %v", err)
+	}
+	actions := []string{}
+	for _, event := range audit {
+		actions = append(actions, event.Action)
+	}
+	expected := []string{"issue", "void", "issue", "reissue"}
+	if len(actions) != len(expected) {
+		t.Fatalf("Audit() actions = %v; want %v", actions, expected)
+	}
+	for i := range expected {
+		if actions[i] != expected[i] {
+			t.Errorf("Audit() actions = %v; want %v", actions, expected)
+			break
+		}
+	}
+	if audit[1].Reason != "wrong amount" {
+		t.Errorf("Audit() void reason = %q; want %q", audit[1].Reason, "wrong amount")
+	}
+}
+
+func TestRelease(t *testing.T) {
+	store := newTestStore(t)
+	date := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	first, _ := store.Next("", "", "", date)
+	second, _ := store.Next("", "", "", date)
+
+	// The latest number of the series is issued again.
+	if err := store.Release(second, "invoice not written"); err != nil {
+		t.Fatalf("Release() returned error: %v", err)
+	}
+	if next, _ := store.Next("", "", "", date); next != second {
+		t.Errorf("Next() after release = %q; want %q", next, second)
+	}
+
+	// An earlier one is voided to keep the series continuous.
+	if err := store.Release(first, "invoice not written"); err != nil {
+		t.Fatalf("Release() returned error: %v", err)
+	}
+	if err := store.Release(first, "again"); !errors.Is(err, ErrInvalidStep) {
+		t.Errorf("Release() of a void number error = %v; want ErrInvalidStep", err)
+	}
+	if err := store.Release("20249999", "typo"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Release() unknown number error = %v; want ErrNotFound", err)
+	}
+
+	entries, err := store.Entries()
+	if err != nil {
+		t.Fatalf("Entries() returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Status != StatusVoid || entries[1].Number != second || entries[1].Status != StatusIssued {
+		t.Errorf("Entries() = %+v; want %s void and %s issued", entries, first, second)
+	}
+	audit, _ := store.Audit()
+	if len(audit) != 5 || audit[2].Action != "release" || audit[4].Action != "void" {
+		t.Errorf("Audit() = %+v; want issue, issue, release, issue, void", audit)
+	}
+}
+
+func TestLockTimeout(t *testing.T) {
+	store := newTestStore(t)
+	store.LockTimeout = 50 * time.Millisecond
+
+	// Another store has its own open lock file, as another process would.
+	unlock, err := NewStore(store.Path).lock()
+	if err != nil {
+		t.Fatalf("lock() returned error: %v", err)
+	}
+	defer unlock()
+
+	_, err = store.Next("", "", "", time.Now())
+	if !errors.Is(err, ErrLocked) {
+		t.Errorf("Next() with held lock error = %v; want ErrLocked", err)
+	}
+}
+
+func TestLeftoverLockFile(t *testing.T) {
+	store := newTestStore(t)
+	store.LockTimeout = 50 * time.Millisecond
+	lockPath := store.Path + ".lock"
+	if err := os.WriteFile(lockPath, []byte("1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file of a crashed run holds no lock.
+	if _, err := store.Next("", "", "", time.Now()); err != nil {
+		t.Errorf("Next() with a leftover lock file returned error: %v", err)
+	}
+	if _, err := store.Next("", "", "", time.Now()); err != nil {
+		t.Errorf("Next() should release the lock after each operation: %v", err)
+	}
+}
@@ -0,0 +1,569 @@
+package qr
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// ErrTooLong is returned when the data does not fit into the largest
+// QR code version at the medium error correction level.
+var ErrTooLong = errors.New("qr: data too long")
+
+const (
+	minVersion = 1
+	maxVersion = 40
+	quietZone  = 4
+)
+
+// Error correction codewords per block and number of blocks for each version
+// at error correction level M, which is what the Czech banking association
+// recommends for QR Platba. Index 0 is unused.
+var (
+	eccCodewordsPerBlock = [maxVersion + 1]int{-1,
+		10, 16, 26, 18, 24, 16, 18, 22, 22, 26,
+		30, 22, 22, 24, 24, 28, 28, 26, 26, 26,
+		26, 28, 28, 28, 28, 28, 28, 28, 28, 28,
+		28, 28, 28, 28, 28, 28, 28, 28, 28, 28,
+	}
+	numErrorCorrectionBlocks = [maxVersion + 1]int{-1,
+		1, 1, 1, 2, 2, 4, 4, 4, 5, 5,
+		5, 8, 9, 9, 10, 10, 11, 13, 14, 16,
+		17, 17, 18, 20, 21, 23, 25, 26, 28, 29,
+		31, 33, 35, 37, 38, 40, 43, 45, 47, 49,
+	}
+)
+
+// Code is an encoded QR code symbol.
+type Code struct {
+	Version int
+	Size    int
+	modules [][]bool
+}
+
+// Black reports whether the module at column x and row y is dark.
+// Coordinates outside the symbol are treated as light (quiet zone).
+func (c *Code) Black(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// Encode encodes text in byte mode at error correction level M using the
+// smallest version that fits.
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+
+	version := minVersion
+	for ; version <= maxVersion; version++ {
+		capacityBits := numDataCodewords(version) * 8
+		if 4+charCountBits(version)+len(data)*8 <= capacityBits {
+			break
+		}
+	}
+	if version > maxVersion {
+		return nil, ErrTooLong
+	}
+
+	codewords := encodeData(data, version)
+	return newCode(version, addErrorCorrection(codewords, version)), nil
+}
+
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// numRawDataModules returns the number of modules available for data and
+// error correction after all function patterns are placed.
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+func numDataCodewords(version int) int {
+	return numRawDataModules(version)/8 - eccCodewordsPerBlock[version]*numErrorCorrectionBlocks[version]
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) appendBits(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+func encodeData(data []byte, version int) []byte {
+	var bits bitBuffer
+	bits.appendBits(0x4, 4) // byte mode
+	bits.appendBits(len(data), charCountBits(version))
+	for _, b := range data {
+		bits.appendBits(int(b), 8)
+	}
+
+	capacityBits := numDataCodewords(version) * 8
+	terminator := min(4, capacityBits-len(bits))
+	bits.appendBits(0, terminator)
+	bits.appendBits(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacityBits; pad ^= 0xEC ^ 0x11 {
+		bits.appendBits(pad, 8)
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			codewords[i>>3] |= 1 << (7 - uint(i&7))
+		}
+	}
+	return codewords
+}
+
+// addErrorCorrection splits data into blocks, appends Reed-Solomon error
+// correction to each and interleaves the result.
+func addErrorCorrection(data []byte, version int) []byte {
+	numBlocks := numErrorCorrectionBlocks[version]
+	blockEccLen := eccCodewordsPerBlock[version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(blockEccLen)
+	blocks := make([][]byte, 0, numBlocks)
+	k := 0
+	for i := 0; i < numBlocks; i++ {
+		dataLen := shortBlockLen - blockEccLen
+		if i >= numShortBlocks {
+			dataLen++
+		}
+		block := append([]byte{}, data[k:k+dataLen]...)
+		k += dataLen
+		ecc := reedSolomonRemainder(block, divisor)
+		if i < numShortBlocks {
+			block = append(block, 0) // placeholder, skipped when interleaving
+		}
+		blocks = append(blocks, append(block, ecc...))
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockEccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= gfMultiply(coef, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+type builder struct {
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+func newCode(version int, codewords []byte) *Code {
+	size := version*4 + 17
+	b := &builder{size: size, modules: newGrid(size), isFunction: newGrid(size)}
+
+	b.drawFunctionPatterns(version)
+	b.drawCodewords(codewords)
+
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		b.applyMask(mask)
+		b.drawFormatBits(mask)
+		if penalty := b.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		b.applyMask(mask) // masking is an XOR, so applying it again undoes it
+	}
+	b.applyMask(bestMask)
+	b.drawFormatBits(bestMask)
+
+	return &Code{Version: version, Size: size, modules: b.modules}
+}
+
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+func (b *builder) set(x, y int, dark bool) {
+	b.modules[y][x] = dark
+	b.isFunction[y][x] = true
+}
+
+func (b *builder) drawFunctionPatterns(version int) {
+	for i := 0; i < b.size; i++ {
+		b.set(6, i, i%2 == 0)
+		b.set(i, 6, i%2 == 0)
+	}
+
+	b.drawFinderPattern(3, 3)
+	b.drawFinderPattern(b.size-4, 3)
+	b.drawFinderPattern(3, b.size-4)
+
+	positions := alignmentPatternPositions(version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue // overlaps a finder pattern
+			}
+			b.drawAlignmentPattern(x, y)
+		}
+	}
+
+	b.drawFormatBits(0) // reserve the area, real bits are drawn after masking
+	b.drawVersion(version)
+}
+
+func (b *builder) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= b.size || yy >= b.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			b.set(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (b *builder) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			b.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+func alignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := 26
+	if version != 32 {
+		step = (version*4 + numAlign*2 + 1) / (numAlign*2 - 2) * 2
+	}
+	result := make([]int, numAlign)
+	result[0] = 6
+	for i, pos := numAlign-1, version*4+10; i >= 1; i, pos = i-1, pos-step {
+		result[i] = pos
+	}
+	return result
+}
+
+func (b *builder) drawFormatBits(mask int) {
+	// Error correction level M is encoded as 00.
+	data := mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>uint(i))&1 != 0 }
+
+	for i := 0; i <= 5; i++ {
+		b.set(8, i, bit(i))
+	}
+	b.set(8, 7, bit(6))
+	b.set(8, 8, bit(7))
+	b.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		b.set(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		b.set(b.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		b.set(8, b.size-15+i, bit(i))
+	}
+	b.set(8, b.size-8, true) // always dark
+}
+
+func (b *builder) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>uint(i))&1 != 0
+		a, c := b.size-11+i%3, i/3
+		b.set(a, c, dark)
+		b.set(c, a, dark)
+	}
+}
+
+// drawCodewords places data bits in the zigzag order defined by the standard,
+// skipping function modules.
+func (b *builder) drawCodewords(data []byte) {
+	i := 0
+	for right := b.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := 0; vert < b.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				upward := (right+1)&2 == 0
+				y := vert
+				if upward {
+					y = b.size - 1 - vert
+				}
+				if b.isFunction[y][x] || i >= len(data)*8 {
+					continue
+				}
+				b.modules[y][x] = (data[i>>3]>>(7-uint(i&7)))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+func (b *builder) applyMask(mask int) {
+	for y := 0; y < b.size; y++ {
+		for x := 0; x < b.size; x++ {
+			if b.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				b.modules[y][x] = !b.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol using the four rules from ISO/IEC 18004 so the
+// least confusing mask can be picked.
+func (b *builder) penalty() int {
+	result := 0
+	at := func(x, y int, transposed bool) bool {
+		if transposed {
+			return b.modules[x][y]
+		}
+		return b.modules[y][x]
+	}
+
+	for _, transposed := range []bool{false, true} {
+		for y := 0; y < b.size; y++ {
+			runLen := 0
+			var runColor bool
+			for x := 0; x < b.size; x++ {
+				dark := at(x, y, transposed)
+				if x > 0 && dark == runColor {
+					runLen++
+					if runLen == 5 {
+						result += 3
+					} else if runLen > 5 {
+						result++
+					}
+				} else {
+					runColor, runLen = dark, 1
+				}
+			}
+
+			for x := -quietZone; x < b.size; x++ {
+				if b.matchesFinderLike(x, y, transposed, at) {
+					result += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < b.size-1; y++ {
+		for x := 0; x < b.size-1; x++ {
+			c := b.modules[y][x]
+			if c == b.modules[y][x+1] && c == b.modules[y+1][x] && c == b.modules[y+1][x+1] {
+				result += 3
+			}
+		}
+	}
+	for y := 0; y < b.size; y++ {
+		for x := 0; x < b.size; x++ {
+			if b.modules[y][x] {
+				dark++
+			}
+		}
+	}
+	total := b.size * b.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	result += k * 10
+
+	return result
+}
+
+// finderLike is the 1:1:3:1:1 pattern preceded or followed by four light modules.
+var finderLike = [2][11]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+func (b *builder) matchesFinderLike(start, line int, transposed bool, at func(x, y int, transposed bool) bool) bool {
+	for _, pattern := range finderLike {
+		matched := true
+		for i, want := range pattern {
+			x := start + i // the quiet zone counts as light modules
+			got := x >= 0 && x < b.size && at(x, line, transposed)
+			if got != want {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Image renders the code with the given number of pixels per module,
+// surrounded by the mandatory quiet zone.
+func (c *Code) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+	side := (c.Size + 2*quietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for py := 0; py < side; py++ {
+		for px := 0; px < side; px++ {
+			shade := color.Gray{Y: 0xFF}
+			if c.Black(px/scale-quietZone, py/scale-quietZone) {
+				shade = color.Gray{Y: 0x00}
+			}
+			img.SetGray(px, py, shade)
+		}
+	}
+	return img
+}
+
+// WritePNG writes the code as a PNG image to w.
+func (c *Code) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, c.Image(scale))
+}
+
+// PNG returns the code encoded as a PNG image.
+func (c *Code) PNG(scale int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.WritePNG(&buf, scale); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Terminal renders the code using Unicode half-block characters, packing two
+// module rows into each line of text. Light modules are drawn as blocks so
+// the code reads correctly on the usual light-on-dark terminal; the quiet
+// zone is included so phone cameras can lock on.
+func (c *Code) Terminal() string {
+	var sb strings.Builder
+	for y := -quietZone; y < c.Size+quietZone; y += 2 {
+		for x := -quietZone; x < c.Size+quietZone; x++ {
+			top, bottom := !c.Black(x, y), !c.Black(x, y+1)
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
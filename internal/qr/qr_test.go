@@ -0,0 +1,162 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// "HELLO WORLD" as version 1-M from the ISO/IEC 18004 worked example.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	result := reedSolomonRemainder(data, reedSolomonDivisor(len(expected)))
+	if !bytes.Equal(result, expected) {
+		t.Errorf("reedSolomonRemainder() = %v; want %v", result, expected)
+	}
+}
+
+func TestNumDataCodewords(t *testing.T) {
+	tests := []struct {
+		version  int
+		expected int
+	}{
+		{1, 16},
+		{2, 28},
+		{7, 124},
+		{10, 216},
+		{40, 2334},
+	}
+
+	for _, tt := range tests {
+		if result := numDataCodewords(tt.version); result != tt.expected {
+			t.Errorf("numDataCodewords(%d) = %d; want %d", tt.version, result, tt.expected)
+		}
+	}
+}
+
+func TestAlignmentPatternPositions(t *testing.T) {
+	tests := []struct {
+		version  int
+		expected []int
+	}{
+		{1, nil},
+		{2, []int{6, 18}},
+		{7, []int{6, 22, 38}},
+		{32, []int{6, 34, 60, 86, 112, 138}},
+		{40, []int{6, 30, 58, 86, 114, 142, 170}},
+	}
+
+	for _, tt := range tests {
+		result := alignmentPatternPositions(tt.version)
+		if len(result) != len(tt.expected) {
+			t.Errorf("alignmentPatternPositions(%d) = %v; want %v", tt.version, result, tt.expected)
+			continue
+		}
+		for i := range result {
+			if result[i] != tt.expected[i] {
+				t.Errorf("alignmentPatternPositions(%d) = %v; want %v", tt.version, result, tt.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestEncodeVersionSelection(t *testing.T) {
+	tests := []struct {
+		length  int
+		version int
+	}{
+		{1, 1},
+		{14, 1},
+		{15, 2},
+		{180, 9},
+		{181, 10},
+	}
+
+	for _, tt := range tests {
+		code, err := Encode(strings.Repeat("a", tt.length))
+		if err != nil {
+			t.Fatalf("Encode(%d bytes) returned error: %v", tt.length, err)
+		}
+		if code.Version != tt.version {
+			t.Errorf("Encode(%d bytes) version = %d; want %d", tt.length, code.Version, tt.version)
+		}
+		if code.Size != tt.version*4+17 {
+			t.Errorf("Encode(%d bytes) size = %d; want %d", tt.length, code.Size, tt.version*4+17)
+		}
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(strings.Repeat("a", 3000)); err != ErrTooLong {
+		t.Errorf("Encode() error = %v; want ErrTooLong", err)
+	}
+}
+
+func TestFinderPatterns(t *testing.T) {
+	code, err := Encode("SPD*1.0*ACC:CZ6508000000192000145399*AM:480.50*CC:CZK")
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+		for i := 0; i < 7; i++ {
+			if !code.Black(corner[0]+i, corner[1]) || !code.Black(corner[0], corner[1]+i) {
+				t.Fatalf("finder pattern at %v is not dark on its border", corner)
+			}
+		}
+		if code.Black(corner[0]+1, corner[1]+1) {
+			t.Errorf("finder pattern at %v should have a light ring", corner)
+		}
+		if !code.Black(corner[0]+3, corner[1]+3) {
+			t.Errorf("finder pattern at %v should have a dark center", corner)
+		}
+	}
+
+	if code.Black(-1, 0) || code.Black(0, code.Size) {
+		t.Error("modules outside the symbol should be light")
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	code, err := Encode("billme")
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	data, err := code.PNG(4)
+	if err != nil {
+		t.Fatalf("PNG() returned error: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("PNG output does not decode: %v", err)
+	}
+
+	expected := (code.Size + 2*quietZone) * 4
+	if img.Bounds().Dx() != expected || img.Bounds().Dy() != expected {
+		t.Errorf("PNG size = %v; want %dx%d", img.Bounds().Size(), expected, expected)
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	code, err := Encode("billme")
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(code.Terminal(), "\n"), "\n")
+	side := code.Size + 2*quietZone
+	if len(lines) != (side+1)/2 {
+		t.Errorf("Terminal() has %d lines; want %d", len(lines), (side+1)/2)
+	}
+	for _, line := range lines {
+		if n := len([]rune(line)); n != side {
+			t.Fatalf("Terminal() line has %d characters; want %d", n, side)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Party describes a supplier or customer as printed on an invoice.
+type Party struct {
+	Name           string `json:"name"`
+	ID             string `json:"id"`     // IČO
+	VATID          string `json:"vat_id"` // DIČ
+	Street         string `json:"street"`
+	BuildingNumber string `json:"building_number"`
+	City           string `json:"city"`
+	PostalCode     string `json:"postal_code"`
+	Country        string `json:"country"` // ISO 3166-1 alpha-2
+	Email          string `json:"email"`
+}
+
+// Client is a customer with its own invoice number series.
+type Client struct {
+	Party
+	Prefix  string `json:"prefix"`  // replaces {PREFIX} in the numbering pattern
+	Pattern string `json:"pattern"` // overrides the default numbering pattern
+}
+
+// Numbering configures invoice numbers.
+type Numbering struct {
+	Pattern   string `json:"pattern"`
+	StateFile string `json:"state_file"`
+}
+
+// Settings holds the values read from the billme config file. Command-line
+// flags always take precedence over them.
+type Settings struct {
+	Rate             float64           `json:"rate"`
+	Currency         string            `json:"currency"`
+	IBAN             string            `json:"iban"`
+	BIC              string            `json:"bic"`
+	VATRate          float64           `json:"vat_rate"`
+	PaymentTermsDays int               `json:"payment_terms_days"`
+	ItemDescription  string            `json:"item_description"`
+	Supplier         Party             `json:"supplier"`
+	Customer         Party             `json:"customer"`
+	Clients          map[string]Client `json:"clients"`
+	Numbering        Numbering         `json:"numbering"`
+	Templates        map[string]string `json:"templates"` // named --format templates
+	Vacation         []string          `json:"vacation"`  // recorded absences: dates and "from..to" ranges
+}
+
+// DefaultPath returns the location of the config file: $BILLME_CONFIG if set,
+// otherwise config.json in the user's configuration directory, e.g.
+// ~/.config/billme/config.json on Linux.
+func DefaultPath() string {
+	if path := os.Getenv("BILLME_CONFIG"); path != "" {
+		return path
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "billme", "config.json")
+}
+
+// Load reads settings from path. A missing file yields empty settings so the
+// tool works without any configuration; pass required=true when the user
+// asked for the file explicitly.
+func Load(path string, required bool) (*Settings, error) {
+	settings := &Settings{}
+	if path == "" {
+		return settings, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !required {
+			return settings, nil
+		}
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	return settings, nil
+}
+
+// CustomerParty returns the invoiced party: the named client, or the default
+// customer when name is empty.
+func (s Settings) CustomerParty(name string) (Party, error) {
+	if name == "" {
+		return s.Customer, nil
+	}
+	client, ok := s.Clients[name]
+	if !ok {
+		return Party{}, fmt.Errorf("unknown client: %s", name)
+	}
+	return client.Party, nil
+}
+
+// Series returns the numbering pattern and prefix for the named client,
+// falling back to the global pattern.
+func (s Settings) Series(name string) (pattern, prefix string, err error) {
+	pattern = s.Numbering.Pattern
+	if name != "" {
+		client, ok := s.Clients[name]
+		if !ok {
+			return "", "", fmt.Errorf("unknown client: %s", name)
+		}
+		prefix = client.Prefix
+		if client.Pattern != "" {
+			pattern = client.Pattern
+		}
+	}
+	return pattern, prefix, nil
+}
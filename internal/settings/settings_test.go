@@ -0,0 +1,124 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{
+		"rate": 6000,
+		"currency": "EUR",
+		"vat_rate": 21,
+		"supplier": {"name": "Jan Novák", "id": "12345678", "vat_id": "CZ12345678"},
+		"customer": {"name": "ACME s.r.o.", "id": "87654321", "country": "SK"},
+		"templates": {"status": "{{.Days}}d"}
+	}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := Load(path, true)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if settings.Rate != 6000 || settings.Currency != "EUR" || settings.VATRate != 21 {
+		t.Errorf("Load() = %+v; unexpected values", settings)
+	}
+	if settings.Supplier.VATID != "CZ12345678" {
+		t.Errorf("Expected supplier VAT ID CZ12345678, got %q", settings.Supplier.VATID)
+	}
+	if settings.Customer.Country != "SK" {
+		t.Errorf("Expected customer country SK, got %q", settings.Customer.Country)
+	}
+	if settings.Templates["status"] != "{{.Days}}d" {
+		t.Errorf("Expected status template, got %q", settings.Templates["status"])
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+
+	settings, err := Load(path, false)
+	if err != nil {
+		t.Fatalf("Load() should ignore a missing optional file: %v", err)
+	}
+	if settings.Rate != 0 {
+		t.Errorf("Expected empty settings, got %+v", settings)
+	}
+
+	if _, err := Load(path, true); err == nil {
+		t.Error("Load() should fail for a missing required file")
+	}
+}
+
+func TestLoadInvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{rate: 6000"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path, false); err == nil {
+		t.Error("Load() should fail for invalid JSON")
+	}
+}
+
+func TestDefaultPathFromEnv(t *testing.T) {
+	t.Setenv("BILLME_CONFIG", "/tmp/billme.json")
+
+	if path := DefaultPath(); path != "/tmp/billme.json" {
+		t.Errorf("DefaultPath() = %q; want /tmp/billme.json", path)
+	}
+}
+
+func TestClients(t *testing.T) {
+	settings := Settings{
+		Customer:  Party{Name: "Default s.r.o."},
+		Numbering: Numbering{Pattern: "{YYYY}{NNNN}"},
+		Clients: map[string]Client{
+			"acme":   {Party: Party{Name: "ACME s.r.o."}, Prefix: "AC"},
+			"globex": {Party: Party{Name: "Globex a.s."}, Prefix: "GX", Pattern: "{PREFIX}{YY}{NNN}"},
+		},
+	}
+
+	party, err := settings.CustomerParty("")
+	if err != nil || party.Name != "Default s.r.o." {
+		t.Errorf("CustomerParty(\"\") = %q, %v; want default customer", party.Name, err)
+	}
+	party, err = settings.CustomerParty("acme")
+	if err != nil || party.Name != "ACME s.r.o." {
+		t.Errorf("CustomerParty(acme) = %q, %v; want ACME s.r.o.", party.Name, err)
+	}
+	if _, err := settings.CustomerParty("initech"); err == nil {
+		t.Error("CustomerParty() should fail for an unknown client")
+	}
+
+	pattern, prefix, _ := settings.Series("acme")
+	if pattern != "{YYYY}{NNNN}" || prefix != "AC" {
+		t.Errorf("Series(acme) = %q, %q; want global pattern with prefix AC", pattern, prefix)
+	}
+	pattern, prefix, _ = settings.Series("globex")
+	if pattern != "{PREFIX}{YY}{NNN}" || prefix != "GX" {
+		t.Errorf("Series(globex) = %q, %q; want client pattern with prefix GX", pattern, prefix)
+	}
+}
+
+func TestLoadClients(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"clients": {"acme": {"name": "ACME s.r.o.", "id": "87654321", "prefix": "AC"}}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := Load(path, true)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	client := settings.Clients["acme"]
+	if client.Name != "ACME s.r.o." || client.ID != "87654321" || client.Prefix != "AC" {
+		t.Errorf("Load() client = %+v; want party fields inline with prefix", client)
+	}
+}
@@ -0,0 +1,181 @@
+package spayd
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	header = "SPD*1.0*"
+
+	maxAmount         = 9999999.99
+	maxMessageLength  = 60
+	maxVariableSymbol = 10
+	maxRecipientName  = 35
+)
+
+// Payment holds the fields of a Czech QR Platba (Short Payment Descriptor).
+type Payment struct {
+	IBAN           string
+	BIC            string
+	Amount         float64
+	Currency       string
+	VariableSymbol string
+	Message        string
+	RecipientName  string
+	DueDate        time.Time
+}
+
+// Encode builds the SPAYD string for the payment. When withCRC32 is set, a
+// CRC32 checksum of the canonical form is appended so banking apps can
+// detect a corrupted code.
+func Encode(p Payment, withCRC32 bool) (string, error) {
+	fields, err := p.fields()
+	if err != nil {
+		return "", err
+	}
+
+	if withCRC32 {
+		fields = append(fields, field{"CRC32", checksum(fields)})
+	}
+
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f.key + ":" + escape(f.value)
+	}
+	return header + strings.Join(parts, "*"), nil
+}
+
+type field struct {
+	key   string
+	value string
+}
+
+func (p Payment) fields() ([]field, error) {
+	iban := normalizeIBAN(p.IBAN)
+	if err := ValidateIBAN(iban); err != nil {
+		return nil, err
+	}
+	account := iban
+	if p.BIC != "" {
+		account += "+" + strings.ToUpper(p.BIC)
+	}
+	fields := []field{{"ACC", account}}
+
+	if p.Amount != 0 {
+		if p.Amount < 0 || p.Amount > maxAmount {
+			return nil, fmt.Errorf("amount out of range: %.2f", p.Amount)
+		}
+		fields = append(fields, field{"AM", formatAmount(p.Amount)})
+	}
+
+	if p.Currency != "" {
+		currency := strings.ToUpper(p.Currency)
+		if len(currency) != 3 || strings.IndexFunc(currency, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+			return nil, fmt.Errorf("invalid currency code: %s", p.Currency)
+		}
+		fields = append(fields, field{"CC", currency})
+	}
+
+	if !p.DueDate.IsZero() {
+		fields = append(fields, field{"DT", p.DueDate.Format("20060102")})
+	}
+
+	if p.Message != "" {
+		if utf8.RuneCountInString(p.Message) > maxMessageLength {
+			return nil, fmt.Errorf("message longer than %d characters", maxMessageLength)
+		}
+		fields = append(fields, field{"MSG", p.Message})
+	}
+
+	if p.RecipientName != "" {
+		if utf8.RuneCountInString(p.RecipientName) > maxRecipientName {
+			return nil, fmt.Errorf("recipient name longer than %d characters", maxRecipientName)
+		}
+		fields = append(fields, field{"RN", p.RecipientName})
+	}
+
+	if p.VariableSymbol != "" {
+		if !isDigits(p.VariableSymbol) || len(p.VariableSymbol) > maxVariableSymbol {
+			return nil, fmt.Errorf("invalid variable symbol: %s (up to %d digits)", p.VariableSymbol, maxVariableSymbol)
+		}
+		fields = append(fields, field{"X-VS", p.VariableSymbol})
+	}
+
+	return fields, nil
+}
+
+// checksum computes the CRC32 of the canonical form: the header followed by
+// all fields sorted by key.
+func checksum(fields []field) string {
+	sorted := append([]field{}, fields...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].key < sorted[j].key })
+
+	parts := make([]string, len(sorted))
+	for i, f := range sorted {
+		parts[i] = f.key + ":" + escape(f.value)
+	}
+	sum := crc32.ChecksumIEEE([]byte(header + strings.Join(parts, "*")))
+	return fmt.Sprintf("%08X", sum)
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*100)/100, 'f', 2, 64)
+}
+
+// escape percent-encodes the field separator and the escape character
+// itself, so values may contain any text.
+func escape(value string) string {
+	return strings.NewReplacer("%", "%25", "*", "%2A").Replace(value)
+}
+
+func normalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+}
+
+// ValidateIBAN checks the length, characters and ISO 13616 mod-97 checksum
+// of an IBAN. Spaces are not allowed; callers should strip them first.
+func ValidateIBAN(iban string) error {
+	if len(iban) < 15 || len(iban) > 34 {
+		return fmt.Errorf("invalid IBAN length: %s", iban)
+	}
+
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		var value int
+		switch {
+		case r >= '0' && r <= '9':
+			value = int(r - '0')
+		case r >= 'A' && r <= 'Z':
+			value = int(r-'A') + 10
+		default:
+			return fmt.Errorf("invalid IBAN character %q: %s", r, iban)
+		}
+		if value >= 10 {
+			remainder = (remainder*100 + value) % 97
+		} else {
+			remainder = (remainder*10 + value) % 97
+		}
+	}
+
+	if remainder != 1 {
+		return fmt.Errorf("invalid IBAN checksum: %s", iban)
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,105 @@
+package spayd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	payment := Payment{
+		IBAN:           "CZ65 0800 0000 1920 0014 5399",
+		Amount:         138000,
+		Currency:       "czk",
+		VariableSymbol: "20240701",
+		Message:        "Faktura 07/2024",
+		DueDate:        time.Date(2024, 8, 14, 0, 0, 0, 0, time.UTC),
+	}
+
+	result, err := Encode(payment, false)
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	expected := "SPD*1.0*ACC:CZ6508000000192000145399*AM:138000.00*CC:CZK*DT:20240814*MSG:Faktura 07/2024*X-VS:20240701"
+	if result != expected {
+		t.Errorf("Encode() = %q; want %q", result, expected)
+	}
+}
+
+func TestEncodeWithBIC(t *testing.T) {
+	result, err := Encode(Payment{IBAN: "CZ6508000000192000145399", BIC: "gibaczpx"}, false)
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	expected := "SPD*1.0*ACC:CZ6508000000192000145399+GIBACZPX"
+	if result != expected {
+		t.Errorf("Encode() = %q; want %q", result, expected)
+	}
+}
+
+func TestEncodeEscaping(t *testing.T) {
+	result, err := Encode(Payment{IBAN: "CZ6508000000192000145399", Message: "50% *discount*"}, false)
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	if !strings.HasSuffix(result, "*MSG:50%25 %2Adiscount%2A") {
+		t.Errorf("Encode() = %q; message not escaped", result)
+	}
+}
+
+func TestEncodeCRC32(t *testing.T) {
+	payment := Payment{IBAN: "CZ6508000000192000145399", Amount: 480.5, Currency: "CZK"}
+
+	result, err := Encode(payment, true)
+	if err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+
+	expected := "SPD*1.0*ACC:CZ6508000000192000145399*AM:480.50*CC:CZK*CRC32:9765EE32"
+	if result != expected {
+		t.Errorf("Encode() = %q; want %q", result, expected)
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		payment Payment
+	}{
+		{"Missing IBAN", Payment{}},
+		{"Bad IBAN checksum", Payment{IBAN: "CZ6508000000192000145398"}},
+		{"Negative amount", Payment{IBAN: "CZ6508000000192000145399", Amount: -1}},
+		{"Amount too large", Payment{IBAN: "CZ6508000000192000145399", Amount: 10000000}},
+		{"Bad currency", Payment{IBAN: "CZ6508000000192000145399", Currency: "KC"}},
+		{"Variable symbol with letters", Payment{IBAN: "CZ6508000000192000145399", VariableSymbol: "2024A"}},
+		{"Variable symbol too long", Payment{IBAN: "CZ6508000000192000145399", VariableSymbol: "12345678901"}},
+		{"Message too long", Payment{IBAN: "CZ6508000000192000145399", Message: strings.Repeat("x", 61)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Encode(tt.payment, false); err == nil {
+				t.Errorf("Encode() should return error for %+v", tt.payment)
+			}
+		})
+	}
+}
+
+func TestValidateIBAN(t *testing.T) {
+	valid := []string{"CZ6508000000192000145399", "CZ6907101781240000004159", "DE89370400440532013000"}
+	for _, iban := range valid {
+		if err := ValidateIBAN(iban); err != nil {
+			t.Errorf("ValidateIBAN(%s) returned error: %v", iban, err)
+		}
+	}
+
+	invalid := []string{"CZ6508000000192000145390", "CZ65", "CZ65-0800-0000-1920-0014-5399"}
+	for _, iban := range invalid {
+		if err := ValidateIBAN(iban); err == nil {
+			t.Errorf("ValidateIBAN(%s) should return error", iban)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/cli"
+	"github.com/honzahovorka/billme/pkg/workdays"
+)
+
+func runIsWorkday(args []string) error {
+	config, err := cli.ParseIsWorkdayArgs(args)
+	if err != nil {
+		return err
+	}
+	if config.Help {
+		cli.ShowIsWorkdayHelp()
+		return nil
+	}
+
+	day, err := cli.IsWorkday(config)
+	if err != nil {
+		return err
+	}
+	if config.Verbose {
+		fmt.Println(cli.FormatWorkday(day))
+	}
+	if day.Kind != workdays.Workday {
+		return cli.ErrNotWorkday
+	}
+	return nil
+}
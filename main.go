@@ -1,18 +1,50 @@
 package main
 
 import (
-	"billme/internal/calculator"
-	"billme/internal/cli"
+	"context"
+	"errors"
 	"fmt"
+	"github.com/honzahovorka/billme/internal/cli"
+	"github.com/honzahovorka/billme/internal/isdoc"
+	"github.com/honzahovorka/billme/internal/qr"
+	"github.com/honzahovorka/billme/internal/spayd"
+	"github.com/honzahovorka/billme/pkg/workdays"
 	"os"
 )
 
+// commands are the subcommands selected by the first argument; anything else
+// is the default billable days calculation.
+var commands = map[string]func(args []string) error{
+	"due":           runDue,
+	"holidays":      runHolidays,
+	"ics":           runICS,
+	"is-workday":    runIsWorkday,
+	"last-workday":  runLastWorkday,
+	"nth":           runNth,
+	"number":        runNumber,
+	"plan-vacation": runPlanVacation,
+	"shift":         runShift,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				if errors.Is(err, cli.ErrNotWorkday) {
+					os.Exit(cli.ExitNotWorkday)
+				}
+				fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Error: %v", cli.Localize(err)))
+				os.Exit(cli.ExitCode(err, cli.ExitError))
+			}
+			return
+		}
+	}
+
 	config, err := cli.ParseArgs()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Error: %v", err))
 		cli.ShowUsage()
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err, cli.ExitUsage))
 	}
 
 	if config.Help {
@@ -20,7 +52,136 @@ func main() {
 		return
 	}
 
-	workingDays := calculator.CountWorkingDaysWithHolidaysAndVacation(config.Month, config.Year, "CZ", config.ExcludeHolidays, config.VacationDays)
-	output := cli.FormatOutput(workingDays, config)
-	fmt.Println(output)
+	period, opts := config.Calculation()
+	if warning := cli.ScheduleWarning(period, opts); warning != "" {
+		fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Warning: %v", warning))
+	}
+	result, err := workdays.Calculate(context.Background(), period, opts)
+	var excess *workdays.ExcessVacationError
+	if errors.As(err, &excess) {
+		fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Warning: %v", cli.Localize(err)))
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Error: %v", cli.Localize(err)))
+		os.Exit(cli.ExitCode(err, cli.ExitError))
+	}
+	workingDays := int(result.Billable)
+
+	if config.Output != "" {
+		output, err := cli.FormatDocument(config.Output, cli.NewReportData(result, config))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Error: %v", cli.Localize(err)))
+			os.Exit(cli.ExitCode(err, cli.ExitError))
+		}
+		fmt.Print(output)
+	} else if config.Format != nil {
+		report := cli.NewReport(config, result.WorkingDays, workingDays, int(result.Elapsed), int(result.Remaining))
+		output, err := cli.FormatReport(config.Format, report)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Error: %v", cli.Localize(err)))
+			os.Exit(cli.ExitCode(err, cli.ExitError))
+		}
+		fmt.Println(output)
+	} else if config.Remaining || config.Elapsed {
+		fmt.Println(cli.FormatProgress(int(result.Elapsed), int(result.Remaining), config))
+	} else {
+		fmt.Println(cli.FormatOutput(workingDays, config))
+		if excluded := cli.FormatExcludedHolidays(result.Days, config); excluded != "" {
+			fmt.Println(excluded)
+		}
+	}
+
+	if config.QR || config.QRPNG != "" {
+		if err := writePaymentQR(workingDays, config); err != nil {
+			fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Error: %v", cli.Localize(err)))
+			os.Exit(cli.ExitCode(err, cli.ExitError))
+		}
+	}
+
+	if config.ISDOC != "" {
+		if err := writeISDOC(workingDays, config); err != nil {
+			fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Error: %v", cli.Localize(err)))
+			os.Exit(cli.ExitCode(err, cli.ExitError))
+		}
+	}
+}
+
+func writePaymentQR(workingDays int, config *cli.Config) error {
+	payload, err := spayd.Encode(cli.Payment(workingDays, config), config.CRC32)
+	if err != nil {
+		return err
+	}
+
+	code, err := qr.Encode(payload)
+	if err != nil {
+		return err
+	}
+
+	if config.QRPNG != "" {
+		file, err := os.Create(config.QRPNG)
+		if err != nil {
+			return err
+		}
+		err = code.WritePNG(file, 8)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.QR {
+		fmt.Print(code.Terminal())
+	}
+
+	return nil
+}
+
+// writeISDOC writes the invoice file. A number taken from the series is
+// issued only once the invoice is known to be valid, and released again if
+// the file cannot be written, so failed runs leave no gaps in the series.
+func writeISDOC(workingDays int, config *cli.Config) error {
+	issued := config.Today
+	invoice, err := cli.Invoice(workingDays, config, issued)
+	if err != nil {
+		return err
+	}
+
+	uuid, err := isdoc.NewUUID()
+	if err != nil {
+		return err
+	}
+	invoice.UUID = uuid
+
+	if invoice.Number != "" {
+		data, err := isdoc.Marshal(invoice)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(config.ISDOC, data, 0o644)
+	}
+
+	draft := invoice
+	draft.Number = "0"
+	if _, err := isdoc.Marshal(draft); err != nil {
+		return err
+	}
+
+	number, err := nextInvoiceNumber(config.Settings, config.Client, issued)
+	if err != nil {
+		return err
+	}
+	invoice.Number = number
+	data, err := isdoc.Marshal(invoice)
+	if err == nil {
+		err = os.WriteFile(config.ISDOC, data, 0o644)
+	}
+	if err != nil {
+		if releaseErr := numberStore(config.Settings).Release(number, "invoice not written"); releaseErr != nil {
+			return fmt.Errorf("%w; invoice number %s stays issued: %v", err, number, releaseErr)
+		}
+		return err
+	}
+	fmt.Fprintln(os.Stderr, cli.Printer().Sprintf("Issued invoice number %s", number))
+	return nil
 }
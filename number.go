@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/cli"
+	"github.com/honzahovorka/billme/internal/numbering"
+	"github.com/honzahovorka/billme/internal/settings"
+	"time"
+)
+
+func runNumber(args []string) error {
+	config, err := cli.ParseNumberArgs(args)
+	if err != nil {
+		return err
+	}
+	if config.Help {
+		cli.ShowNumberHelp()
+		return nil
+	}
+
+	store := numbering.NewStore(config.StateFile)
+
+	switch config.Action {
+	case "next":
+		number, err := nextInvoiceNumber(config.Settings, config.Client, config.Date)
+		if err != nil {
+			return err
+		}
+		fmt.Println(number)
+	case "void":
+		if err := store.Void(config.Number, config.Reason); err != nil {
+			return err
+		}
+		fmt.Println(cli.Printer().Sprintf("Voided %s", config.Number))
+	case "reissue":
+		if err := store.Reissue(config.Number, config.Reason); err != nil {
+			return err
+		}
+		fmt.Println(cli.Printer().Sprintf("Reissued %s", config.Number))
+	case "list":
+		entries, err := store.Entries()
+		if err != nil {
+			return err
+		}
+		audit, err := store.Audit()
+		if err != nil {
+			return err
+		}
+		fmt.Println(cli.FormatNumberEntries(entries, audit))
+	}
+
+	return nil
+}
+
+// nextInvoiceNumber issues the next number of the client's series from the
+// configured state file.
+func nextInvoiceNumber(s settings.Settings, client string, date time.Time) (string, error) {
+	pattern, prefix, err := s.Series(client)
+	if err != nil {
+		return "", err
+	}
+	return numberStore(s).Next(pattern, prefix, client, date)
+}
+
+// numberStore returns the store of the configured state file.
+func numberStore(s settings.Settings) *numbering.Store {
+	path := s.Numbering.StateFile
+	if path == "" {
+		path = numbering.DefaultPath()
+	}
+	return numbering.NewStore(path)
+}
@@ -0,0 +1,294 @@
+// Package workdays calculates the working and billable days of a period,
+// with public holidays and leave, day by day.
+//
+// It is the public API of billme: the billme command is built on it, and it
+// follows semantic versioning with the module's release tags. Within a major
+// version, exported names are not removed or changed incompatibly; new
+// fields may be added to Options and Result, so construct them with field
+// names.
+//
+//	result, err := workdays.Calculate(ctx, workdays.Month(2024, time.July), workdays.Options{
+//		Country:         "CZ",
+//		ExcludeHolidays: true,
+//		VacationDays:    2,
+//	})
+//	// result.Billable == 20
+package workdays
+
+import (
+	"context"
+	"github.com/honzahovorka/billme/internal/calculator"
+	"github.com/honzahovorka/billme/internal/holidays"
+	"time"
+)
+
+// DayKind classifies a day of the period.
+type DayKind = calculator.DayKind
+
+// Day kinds.
+const (
+	Workday  = calculator.Workday
+	Weekend  = calculator.Weekend
+	Holiday  = calculator.Holiday  // a public holiday excluded from the workdays
+	Vacation = calculator.Vacation // a workday taken off entirely
+	Partial  = calculator.Partial  // a workday taken off in part
+)
+
+// Day is one day of the period: its date, weekday, kind, the local name of
+// the public holiday on it (even when it is not excluded) with the holidays
+// themselves, the special working day it is, if any, and the billable part
+// of it from 0 to 1.
+type Day = calculator.Day
+
+// Leave is time off on a date. Fraction is the part of the day taken off;
+// zero means the whole day.
+type Leave = calculator.Leave
+
+// WorkWeek is the set of weekdays normally worked.
+type WorkWeek = calculator.WorkWeek
+
+// DefaultWorkWeek is Monday to Friday.
+const DefaultWorkWeek = calculator.DefaultWorkWeek
+
+// ParseWorkWeek parses a list of weekdays and ranges such as "mon-fri" or
+// "sun-thu".
+func ParseWorkWeek(value string) (WorkWeek, error) {
+	return calculator.ParseWorkWeek(value)
+}
+
+// PublicHoliday is a holiday as returned by a HolidayProvider: its ID, local
+// and English names, date, type, whether shops close, its legal source and,
+// from a CombinedProvider, its country. NameIn picks the name for a
+// language.
+type PublicHoliday = holidays.Holiday
+
+// HolidayType is the legal category of a holiday; the zero value is
+// HolidayPublic.
+type HolidayType = holidays.HolidayType
+
+// Holiday types.
+const (
+	HolidayPublic     = holidays.Public
+	HolidayBank       = holidays.Bank
+	HolidayObservance = holidays.Observance
+	HolidayOptional   = holidays.Optional
+	HolidayRegional   = holidays.Regional
+)
+
+// HolidayTypes is a set of holiday types.
+type HolidayTypes = holidays.HolidayTypes
+
+// DefaultHolidayTypes are public and regional holidays, the days off for
+// everyone where they apply.
+const DefaultHolidayTypes = holidays.DefaultHolidayTypes
+
+// HolidayTypesOf returns the set of the given types.
+func HolidayTypesOf(types ...HolidayType) HolidayTypes {
+	return holidays.TypesOf(types...)
+}
+
+// ParseHolidayTypes parses a comma-separated list of holiday types such as
+// "public,bank", or "all". An unknown type is reported with a
+// *HolidayTypeError.
+func ParseHolidayTypes(value string) (HolidayTypes, error) {
+	return holidays.ParseHolidayTypes(value)
+}
+
+// HolidayTypeError reports an unknown holiday type to ParseHolidayTypes.
+type HolidayTypeError = holidays.HolidayTypeError
+
+// HolidayProvider supplies the holidays of a year. Implement it to use
+// holidays billme does not know, such as company-wide days off.
+type HolidayProvider = holidays.HolidayProvider
+
+// RegionalProvider is a HolidayProvider whose holidays differ between
+// regions of the country.
+type RegionalProvider = holidays.RegionalProvider
+
+// CombinedProvider is a HolidayProvider of the holidays of several
+// countries or regions together, as Calculate builds for a Country such as
+// "CZ+DE-BY".
+type CombinedProvider = holidays.CombinedProvider
+
+// Jurisdiction is a country or region of a CombinedProvider.
+type Jurisdiction = holidays.Jurisdiction
+
+// WorkingDayProvider is a HolidayProvider of a country that declares
+// weekend days working days to make up for days off, as China does. With
+// ExcludeHolidays, Calculate counts them as workdays and sets
+// Day.WorkingDay.
+type WorkingDayProvider = holidays.WorkingDayProvider
+
+// ScheduledProvider is a HolidayProvider of a country whose days off follow
+// a schedule published every year, as China's do; years without a known
+// schedule have the statutory holidays only.
+type ScheduledProvider = holidays.ScheduledProvider
+
+// RoshHashanah returns the first day of the Jewish New Year in the year.
+func RoshHashanah(year int) time.Time {
+	return holidays.RoshHashanah(year)
+}
+
+// HebrewDate returns the Gregorian date of a day of the Hebrew calendar,
+// with months numbered from Nisan (1) to Adar II (13).
+func HebrewDate(year, month, day int) time.Time {
+	return holidays.HebrewDate(year, month, day)
+}
+
+// EidAlFitr returns the days of Eid al-Fitr in the year by the tabular
+// Islamic calendar, which may differ by a day or two from the sighting of
+// the crescent.
+func EidAlFitr(year int) []time.Time {
+	return holidays.EidAlFitr(year)
+}
+
+// IslamicDates returns the dates in the Gregorian year of a day of the
+// tabular Islamic calendar, with months numbered from Muharram (1).
+func IslamicDates(year, month, day int) []time.Time {
+	return holidays.IslamicDates(year, month, day)
+}
+
+// LunarNewYear returns the first day of the Chinese lunisolar year that
+// begins in the year, exactly from 1900 to 2100.
+func LunarNewYear(year int) time.Time {
+	return holidays.LunarNewYear(year)
+}
+
+// ChineseDate returns the Gregorian date of a day of the Chinese year that
+// begins in the year, e.g. ChineseDate(2024, 8, 15) for the Mid-Autumn
+// Festival; leap months are skipped. Dates are exact from 1900 to 2100.
+func ChineseDate(year, month, day int) time.Time {
+	return holidays.ChineseDate(year, month, day)
+}
+
+// Errors returned by Calculate; match them with errors.As.
+type (
+	YearError           = calculator.YearError
+	PeriodError         = calculator.PeriodError
+	CountryError        = calculator.CountryError
+	RegionError         = calculator.RegionError
+	VacationError       = calculator.VacationError
+	ExcessVacationError = calculator.ExcessVacationError
+)
+
+// MinYear and MaxYear bound the years Calculate accepts.
+const (
+	MinYear = calculator.MinYear
+	MaxYear = calculator.MaxYear
+)
+
+// Period is a range of dates, both ends included. Only the dates count; the
+// time of day and location are ignored.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Month returns the period of a calendar month.
+func Month(year int, month time.Month) Period {
+	return Months(year, month, year, month)
+}
+
+// Months returns the period from the first day of one month to the last day
+// of another.
+func Months(year int, month time.Month, endYear int, endMonth time.Month) Period {
+	return Period{
+		Start: time.Date(year, month, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(endYear, endMonth+1, 0, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// Options control how days are classified and counted. The zero value counts
+// Monday to Friday with no holidays and no leave.
+type Options struct {
+	// Country selects the public holidays by ISO 3166-1 code. Empty means
+	// none. Codes with a region, as in "DE-BY", and several joined by "+",
+	// as in "CZ+DE-BY", combine the holidays of every jurisdiction, each
+	// attributed to its code in PublicHoliday.Country.
+	Country string
+	// Region narrows the holidays to a subdivision of the country, for
+	// providers that implement RegionalProvider.
+	Region string
+	// Provider supplies the holidays instead of Country when set.
+	Provider HolidayProvider
+	// Intersect keeps only the holidays of the combined countries that are
+	// days off, of HolidayTypes, in all of them, instead of those of any.
+	Intersect bool
+	// ExcludeHolidays leaves public holidays out of the workdays and counts
+	// the special working days of a WorkingDayProvider. Without it holidays
+	// are still named in the days but count as workdays.
+	ExcludeHolidays bool
+	// HolidayTypes are the types of holiday ExcludeHolidays leaves out;
+	// zero means DefaultHolidayTypes.
+	HolidayTypes HolidayTypes
+	// WorkWeek is the set of weekdays normally worked; zero means
+	// DefaultWorkWeek.
+	WorkWeek WorkWeek
+	// Leave is time off on specific dates. Leave on weekends and excluded
+	// holidays is ignored.
+	Leave []Leave
+	// VacationDays is a number of undated days off, taken from the total.
+	VacationDays int
+	// Today splits the billable days into elapsed and remaining. The zero
+	// value leaves Elapsed and Remaining at zero.
+	Today time.Time
+}
+
+// Result is the outcome of Calculate.
+type Result struct {
+	Period Period
+	// Days holds every day of the period in order.
+	Days []Day
+	// WorkingDays are the workdays of the period before any leave.
+	WorkingDays int
+	// Holidays are the public holidays excluded from the workdays.
+	Holidays int
+	// VacationDays are the days of leave taken from the workdays: whole and
+	// partial dated leave plus Options.VacationDays.
+	VacationDays float64
+	// Billable is WorkingDays less VacationDays, never below zero.
+	Billable float64
+	// Elapsed are the billable days before Options.Today and Remaining the
+	// ones from that day on, less Options.VacationDays.
+	Elapsed   float64
+	Remaining float64
+}
+
+// Calculate classifies every day of period and counts its billable days.
+//
+// Invalid input is reported with a *YearError, *PeriodError, *CountryError,
+// *RegionError or *VacationError. When the vacation exceeds the working days, the result
+// is still valid, billing zero days, and the error is an
+// *ExcessVacationError that callers may treat as a warning. A cancelled ctx
+// returns its error.
+func Calculate(ctx context.Context, period Period, opts Options) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	r, err := calculator.Calculate(period.Start, period.End, calculator.Options{
+		Country:         opts.Country,
+		Region:          opts.Region,
+		Provider:        opts.Provider,
+		Intersect:       opts.Intersect,
+		ExcludeHolidays: opts.ExcludeHolidays,
+		HolidayTypes:    opts.HolidayTypes,
+		WorkWeek:        opts.WorkWeek,
+		Leave:           opts.Leave,
+		VacationDays:    opts.VacationDays,
+		Today:           opts.Today,
+	})
+	if r.Days == nil {
+		return Result{}, err
+	}
+	return Result{
+		Period:       Period{Start: r.Days[0].Date, End: r.Days[len(r.Days)-1].Date},
+		Days:         r.Days,
+		WorkingDays:  r.WorkingDays,
+		Holidays:     r.Holidays,
+		VacationDays: r.VacationDays,
+		Billable:     r.Billable,
+		Elapsed:      r.Elapsed,
+		Remaining:    r.Remaining,
+	}, err
+}
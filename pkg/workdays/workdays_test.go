@@ -0,0 +1,202 @@
+package workdays
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func date(year, month, day int) time.Time {
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name         string
+		period       Period
+		opts         Options
+		workingDays  int
+		holidays     int
+		vacationDays float64
+		billable     float64
+	}{
+		{"Plain month", Month(2024, time.July), Options{}, 23, 0, 0, 23},
+		{"Holidays named but counted", Month(2024, time.July), Options{Country: "CZ"}, 23, 0, 0, 23},
+		{"Holidays excluded", Month(2024, time.July), Options{Country: "cz", ExcludeHolidays: true}, 22, 1, 0, 22},
+		{"Vacation days", Month(2024, time.July), Options{Country: "CZ", ExcludeHolidays: true, VacationDays: 2}, 22, 1, 2, 20},
+		{"Dated leave", Month(2024, time.July), Options{Country: "CZ", ExcludeHolidays: true, Leave: []Leave{
+			{Date: date(2024, 7, 8)}, {Date: date(2024, 7, 9), Fraction: 0.5}, {Date: date(2024, 7, 6)},
+		}}, 22, 1, 1.5, 20.5},
+		{"Vacation clamped", Month(2024, time.July), Options{VacationDays: 40}, 23, 0, 40, 0},
+		{"Quarter", Months(2024, time.July, 2024, time.September), Options{Country: "CZ", ExcludeHolidays: true}, 65, 1, 0, 65},
+		{"Across years", Months(2024, time.November, 2025, time.January), Options{Country: "CZ", ExcludeHolidays: true}, 62, 4, 0, 62},
+		{"Part of a month", Period{date(2024, 7, 4), time.Date(2024, 7, 10, 17, 0, 0, 0, time.Local)}, Options{Country: "CZ", ExcludeHolidays: true}, 4, 1, 0, 4},
+		{"Regional holidays", Month(2024, time.May), Options{Country: "DE", Region: "BY", ExcludeHolidays: true}, 19, 4, 0, 19},
+		{"Public holidays only", Month(2024, time.May), Options{Country: "DE", Region: "BY", ExcludeHolidays: true, HolidayTypes: HolidayTypesOf(HolidayPublic)}, 20, 3, 0, 20},
+		{"Holidays of either country", Month(2024, time.May), Options{Country: "CZ+DE-BY", ExcludeHolidays: true}, 18, 5, 0, 18},
+		{"Holidays of both countries", Month(2024, time.May), Options{Country: "CZ+DE-BY", Intersect: true, ExcludeHolidays: true}, 22, 1, 0, 22},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Calculate(context.Background(), tt.period, tt.opts)
+			var excess *ExcessVacationError
+			if err != nil && !errors.As(err, &excess) {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if result.WorkingDays != tt.workingDays || result.Holidays != tt.holidays ||
+				result.VacationDays != tt.vacationDays || result.Billable != tt.billable {
+				t.Errorf("Calculate() = %d working, %d holidays, %v vacation, %v billable; want %d, %d, %v, %v",
+					result.WorkingDays, result.Holidays, result.VacationDays, result.Billable,
+					tt.workingDays, tt.holidays, tt.vacationDays, tt.billable)
+			}
+		})
+	}
+}
+
+type companyDays struct{}
+
+func (companyDays) GetHolidays(year int) []PublicHoliday {
+	return []PublicHoliday{{Name: "Company day", Date: date(year, 7, 10)}}
+}
+
+func TestCalculateProviderAndWorkWeek(t *testing.T) {
+	week, err := ParseWorkWeek("mon-thu")
+	if err != nil {
+		t.Fatalf("ParseWorkWeek() error = %v", err)
+	}
+	result, err := Calculate(context.Background(), Month(2024, time.July), Options{
+		Country:         "XX", // ignored with a provider
+		Provider:        companyDays{},
+		ExcludeHolidays: true,
+		WorkWeek:        week,
+	})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if result.Billable != 18 || result.Days[9].Holiday != "Company day" {
+		t.Errorf("Calculate() = %v billable, July 10 %q; want 18, Company day", result.Billable, result.Days[9].Holiday)
+	}
+}
+
+type lunarDays struct{}
+
+func (lunarDays) GetHolidays(year int) []PublicHoliday {
+	days := []PublicHoliday{
+		{Name: "Lunar New Year", Date: LunarNewYear(year)},
+		{Name: "Rosh Hashanah", Date: RoshHashanah(year)},
+	}
+	for _, eid := range EidAlFitr(year) {
+		days = append(days, PublicHoliday{Name: "Eid al-Fitr", Date: eid})
+	}
+	return days
+}
+
+func TestCalculateLunarHolidays(t *testing.T) {
+	tests := []struct {
+		month    time.Month
+		holiday  string
+		day      int
+		billable float64
+	}{
+		{time.February, "Lunar New Year", 10, 21}, // a Saturday
+		{time.April, "Eid al-Fitr", 10, 21},
+		{time.October, "Rosh Hashanah", 3, 22},
+	}
+	for _, tt := range tests {
+		result, err := Calculate(context.Background(), Month(2024, tt.month), Options{Provider: lunarDays{}, ExcludeHolidays: true})
+		if err != nil {
+			t.Fatalf("Calculate() error = %v", err)
+		}
+		if result.Billable != tt.billable || result.Days[tt.day-1].Holiday != tt.holiday {
+			t.Errorf("%s: %v billable, day %d %q; want %v, %s", tt.month, result.Billable, tt.day, result.Days[tt.day-1].Holiday, tt.billable, tt.holiday)
+		}
+	}
+}
+
+func TestCalculateDays(t *testing.T) {
+	result, err := Calculate(context.Background(), Month(2024, time.July), Options{Country: "CZ", ExcludeHolidays: true})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if len(result.Days) != 31 {
+		t.Fatalf("Calculate() returned %d days, want 31", len(result.Days))
+	}
+	if day := result.Days[4]; day.Kind != Holiday || day.Weekday != time.Friday || day.Holiday == "" {
+		t.Errorf("July 5 = %v %v %q; want a named holiday on Friday", day.Kind, day.Weekday, day.Holiday)
+	}
+	if holidays := result.Days[5].Holidays; len(holidays) != 1 || holidays[0].NameIn("en") != "Jan Hus Day" || holidays[0].Type != HolidayPublic {
+		t.Errorf("July 6 holidays = %+v; want the public holiday Jan Hus Day", holidays)
+	}
+}
+
+func TestCalculateToday(t *testing.T) {
+	tests := []struct {
+		name      string
+		today     time.Time
+		opts      Options
+		elapsed   float64
+		remaining float64
+	}{
+		{"Mid-month", time.Date(2024, 7, 15, 9, 30, 0, 0, time.UTC), Options{}, 10, 13},
+		{"Vacation from remaining", date(2024, 7, 15), Options{VacationDays: 3}, 10, 10},
+		{"Leave where it falls", date(2024, 7, 15), Options{Leave: []Leave{{Date: date(2024, 7, 8)}}}, 9, 13},
+		{"Month over", date(2024, 8, 5), Options{VacationDays: 3}, 23, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Today = tt.today
+			result, err := Calculate(context.Background(), Month(2024, time.July), tt.opts)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if result.Elapsed != tt.elapsed || result.Remaining != tt.remaining {
+				t.Errorf("Calculate() = %v elapsed, %v remaining; want %v, %v", result.Elapsed, result.Remaining, tt.elapsed, tt.remaining)
+			}
+		})
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		period Period
+		opts   Options
+		target any
+	}{
+		{"Period reversed", context.Background(), Period{date(2024, 7, 10), date(2024, 7, 1)}, Options{}, new(*PeriodError)},
+		{"Year out of range", context.Background(), Month(1500, time.July), Options{}, new(*YearError)},
+		{"Unknown country", context.Background(), Month(2024, time.July), Options{Country: "XX"}, new(*CountryError)},
+		{"Unknown region", context.Background(), Month(2024, time.July), Options{Country: "DE", Region: "XX"}, new(*RegionError)},
+		{"Negative vacation", context.Background(), Month(2024, time.July), Options{VacationDays: -2}, new(*VacationError)},
+		{"Cancelled", cancelled, Month(2024, time.July), Options{}, new(error)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Calculate(tt.ctx, tt.period, tt.opts); !errors.As(err, tt.target) {
+				t.Errorf("Calculate() error = %v; want %T", err, tt.target)
+			}
+		})
+	}
+}
+
+func ExampleCalculate() {
+	result, err := Calculate(context.Background(), Month(2024, time.July), Options{
+		Country:         "CZ",
+		ExcludeHolidays: true,
+		VacationDays:    2,
+	})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(result.WorkingDays, result.Billable)
+	// Output: 22 20
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/cli"
+)
+
+func runPlanVacation(args []string) error {
+	config, err := cli.ParsePlanArgs(args)
+	if err != nil {
+		return err
+	}
+	if config.Help {
+		cli.ShowPlanHelp()
+		return nil
+	}
+
+	calendar, err := config.Calendar()
+	if err != nil {
+		return err
+	}
+	plan, err := cli.NewVacationPlan(config, calendar.PlanVacation(config.Year, config.Days))
+	if err != nil {
+		return err
+	}
+	fmt.Println(cli.FormatPlan(plan, config))
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"github.com/honzahovorka/billme/internal/cli"
+)
+
+func runShift(args []string) error {
+	config, err := cli.ParseShiftArgs(args)
+	if err != nil {
+		return err
+	}
+	if config.Help {
+		cli.ShowWorkdaysHelp()
+		return nil
+	}
+
+	calendar, err := config.Calendar()
+	if err != nil {
+		return err
+	}
+	day := calendar.AddWorkingDays(config.Date, config.Days)
+	fmt.Println(cli.FormatDate(day))
+	return nil
+}
+
+func runNth(args []string) error {
+	return printNth(cli.ParseNthArgs(args))
+}
+
+func runLastWorkday(args []string) error {
+	return printNth(cli.ParseLastWorkdayArgs(args))
+}
+
+func printNth(config *cli.NthConfig, err error) error {
+	if err != nil {
+		return err
+	}
+	if config.Help {
+		cli.ShowWorkdaysHelp()
+		return nil
+	}
+
+	calendar, err := config.Calendar()
+	if err != nil {
+		return err
+	}
+	day, ok := calendar.NthWorkingDay(config.Month, config.Year, config.N)
+	if !ok {
+		return cli.TooFewWorkingDays(config)
+	}
+	fmt.Println(cli.FormatDate(day))
+	return nil
+}